@@ -0,0 +1,51 @@
+package traceid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+)
+
+// Authenticate returns id with its low bits bits of Low replaced by a tag
+// authenticating the remaining 128-bits bits under key. The tag is computed
+// as follows:
+//
+//  1. clear the low bits bits of Low
+//  2. compute HMAC-SHA256 with key over the 16 bytes of Bytes (High
+//     big-endian, then the cleared Low big-endian)
+//  3. read the first 8 bytes of the MAC as a big-endian uint64 and keep its
+//     top bits bits
+//
+// bits outside [1, 64] return id unchanged. Low of the result is zero when
+// the kept bits of Low and the tag all are, which is certain to happen now and
+// then for small tags over 64 bit ids; generators must draw another id then.
+func Authenticate(id TraceID, key []byte, bits uint) TraceID {
+	if bits < 1 || bits > 64 {
+		return id
+	}
+	mask := ^uint64(0) >> (64 - bits)
+	id.Low &^= mask
+	id.Low |= authenticationTag(id, key) >> (64 - bits)
+	return id
+}
+
+// VerifyAuthenticated returns if id carries a valid tag of width bits under
+// key, as written by Authenticate. The tags are compared in constant time.
+func VerifyAuthenticated(id TraceID, key []byte, bits uint) bool {
+	if bits < 1 || bits > 64 {
+		return false
+	}
+	var got, want [8]byte
+	binary.BigEndian.PutUint64(got[:], id.Low)
+	binary.BigEndian.PutUint64(want[:], Authenticate(id, key, bits).Low)
+	return subtle.ConstantTimeCompare(got[:], want[:]) == 1
+}
+
+// authenticationTag returns the first 8 bytes of the HMAC-SHA256 of id.
+func authenticationTag(id TraceID, key []byte) uint64 {
+	b := id.Bytes()
+	mac := hmac.New(sha256.New, key)
+	mac.Write(b[:])
+	return binary.BigEndian.Uint64(mac.Sum(nil)[:8])
+}
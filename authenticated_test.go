@@ -0,0 +1,56 @@
+package traceid
+
+import (
+	"testing"
+)
+
+func TestAuthenticate(t *testing.T) {
+	key := []byte("edge secret")
+	ids := []TraceID{
+		{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736},
+		{Low: 0xab},
+		{High: ^uint64(0), Low: ^uint64(0)},
+	}
+	for _, bits := range []uint{1, 8, 16, 32, 63, 64} {
+		for _, id := range ids {
+			tagged := Authenticate(id, key, bits)
+			if !VerifyAuthenticated(tagged, key, bits) {
+				t.Errorf("bits %d: %v does not verify", bits, tagged)
+			}
+			if tagged.High != id.High || bits < 64 && tagged.Low>>bits != id.Low>>bits {
+				t.Errorf("bits %d: Authenticate(%v) = %v changed more than the tag", bits, id, tagged)
+			}
+		}
+	}
+}
+
+func TestVerifyAuthenticatedRejects(t *testing.T) {
+	key := []byte("edge secret")
+	id := Authenticate(TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}, key, 16)
+
+	if VerifyAuthenticated(id, []byte("other secret"), 16) {
+		t.Error("verified under a wrong key")
+	}
+	for bit := uint(0); bit < 128; bit++ {
+		tampered := id
+		if bit < 64 {
+			tampered.Low ^= 1 << bit
+		} else {
+			tampered.High ^= 1 << (bit - 64)
+		}
+		if VerifyAuthenticated(tampered, key, 16) {
+			t.Errorf("verified with bit %d flipped", bit)
+		}
+	}
+	if VerifyAuthenticated(id, key, 32) {
+		t.Error("verified with a different tag width")
+	}
+	for _, bits := range []uint{0, 65} {
+		if VerifyAuthenticated(id, key, bits) {
+			t.Errorf("verified with tag width %d", bits)
+		}
+		if got := Authenticate(id, key, bits); got != id {
+			t.Errorf("Authenticate with width %d = %v, want %v unchanged", bits, got, id)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package traceid
+
+import (
+	"github.com/kataras/iris/core/errors"
+)
+
+// base58 is the Bitcoin base58 alphabet, leaving out 0, O, I and l.
+const base58 = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// base58 parse errors, use Equal to match them
+var (
+	ErrBase58Empty    = errors.New("empty base58 trace id")
+	ErrBase58Char     = errors.New("invalid base58 character %q at position %d")
+	ErrBase58Overflow = errors.New("base58 trace id %q overflows 128 bits")
+)
+
+// Base58 outputs the 16 bytes of Bytes in base58 with the Bitcoin alphabet,
+// usually 22 characters. Like Bitcoin every leading zero byte is written as
+// '1', so the length varies for ids with zero upper bytes.
+func (t TraceID) Base58() string {
+	b := t.Bytes()
+	zeros := 0
+	for zeros < len(b) && b[zeros] == 0 {
+		zeros++
+	}
+	// digits in little-endian order
+	digits := make([]byte, 0, 22)
+	for _, c := range b[zeros:] {
+		carry := int(c)
+		for i := range digits {
+			carry += int(digits[i]) << 8
+			digits[i] = byte(carry % 58)
+			carry /= 58
+		}
+		for carry > 0 {
+			digits = append(digits, byte(carry%58))
+			carry /= 58
+		}
+	}
+	out := make([]byte, zeros+len(digits))
+	for i := 0; i < zeros; i++ {
+		out[i] = base58[0]
+	}
+	for i, d := range digits {
+		out[len(out)-1-i] = base58[d]
+	}
+	return string(out)
+}
+
+// TraceIDFromBase58 returns the TraceID from its Base58 representation.
+func TraceIDFromBase58(s string) (TraceID, error) {
+	if s == "" {
+		return TraceID{}, ErrBase58Empty
+	}
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58[0] {
+		zeros++
+	}
+	if zeros > 16 {
+		return TraceID{}, ErrBase58Overflow.Format(s)
+	}
+	// bytes in little-endian order
+	var acc []byte
+	for i := zeros; i < len(s); i++ {
+		v := base58Value(s[i])
+		if v < 0 {
+			return TraceID{}, ErrBase58Char.Format(s[i], i)
+		}
+		carry := v
+		for j := range acc {
+			carry += int(acc[j]) * 58
+			acc[j] = byte(carry)
+			carry >>= 8
+		}
+		for carry > 0 {
+			acc = append(acc, byte(carry))
+			carry >>= 8
+		}
+		if zeros+len(acc) > 16 {
+			return TraceID{}, ErrBase58Overflow.Format(s)
+		}
+	}
+	var b [16]byte
+	for i, c := range acc {
+		b[15-i] = c
+	}
+	return TraceIDFromBytes(b), nil
+}
+
+func base58Value(c byte) int {
+	for i := 0; i < len(base58); i++ {
+		if base58[i] == c {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,32 @@
+package traceid
+
+import (
+	"encoding/base64"
+
+	"github.com/kataras/iris/core/errors"
+)
+
+// base64url parse errors, use Equal to match them
+var (
+	ErrBase64Length = errors.New("base64url trace id must be 22 characters, got %d")
+	ErrBase64Char   = errors.New("invalid base64url trace id %q")
+)
+
+// Base64URL outputs the 16 bytes of Bytes as the 22 character unpadded
+// base64url encoding of RFC 4648 section 5, safe in URLs and JWTs.
+func (t TraceID) Base64URL() string {
+	b := t.Bytes()
+	return base64.RawURLEncoding.EncodeToString(b[:])
+}
+
+// TraceIDFromBase64URL returns the TraceID from its Base64URL representation.
+func TraceIDFromBase64URL(s string) (TraceID, error) {
+	if len(s) != 22 {
+		return TraceID{}, ErrBase64Length.Format(len(s))
+	}
+	var b [16]byte
+	if _, err := base64.RawURLEncoding.Strict().Decode(b[:], []byte(s)); err != nil {
+		return TraceID{}, ErrBase64Char.Format(s)
+	}
+	return TraceIDFromBytes(b), nil
+}
@@ -0,0 +1,120 @@
+package traceid
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+var (
+	binaryID    = TraceID{High: 0x0102030405060708, Low: 0x090a0b0c0d0e0f10}
+	binaryBytes = []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}
+)
+
+func TestMarshalBinary(t *testing.T) {
+	b, err := binaryID.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, binaryBytes) {
+		t.Errorf("MarshalBinary() = %x, want %x", b, binaryBytes)
+	}
+	b, _ = TraceID{Low: 0xab}.MarshalBinary()
+	if want := []byte{15: 0xab}; !bytes.Equal(b, want) {
+		t.Errorf("MarshalBinary() of a 64 bit id = %x, want %x", b, want)
+	}
+}
+
+func TestUnmarshalBinary(t *testing.T) {
+	var id TraceID
+	if err := id.UnmarshalBinary(binaryBytes); err != nil || id != binaryID {
+		t.Errorf("UnmarshalBinary(%x) = %v, %v, want %v", binaryBytes, id, err, binaryID)
+	}
+	if err := id.UnmarshalBinary(binaryBytes[8:]); err != nil || id != (TraceID{Low: 0x090a0b0c0d0e0f10}) {
+		t.Errorf("UnmarshalBinary(%x) = %#v, %v, want Low only", binaryBytes[8:], id, err)
+	}
+	for _, n := range []int{0, 7, 9, 15, 17} {
+		id = binaryID
+		err := id.UnmarshalBinary(make([]byte, n))
+		if err == nil || !ErrBinaryLength.Equal(err) {
+			t.Errorf("UnmarshalBinary of %d bytes error = %v, want ErrBinaryLength", n, err)
+			continue
+		}
+		if n == 7 && err.Error() != "cannot read a TraceID from 7 bytes, want 8 or 16" {
+			t.Errorf("error = %q", err)
+		}
+		if id != binaryID {
+			t.Errorf("UnmarshalBinary of %d bytes changed the id to %v", n, id)
+		}
+	}
+}
+
+func TestGob(t *testing.T) {
+	type span struct {
+		TraceID TraceID
+		Name    string
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(span{binaryID, "root"}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(buf.Bytes(), binaryBytes) {
+		t.Errorf("gob stream %x does not carry the 16 bytes of MarshalBinary", buf.Bytes())
+	}
+	var got span
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.TraceID != binaryID || got.Name != "root" {
+		t.Errorf("gob round trip = %+v", got)
+	}
+}
+
+func TestPutBytes(t *testing.T) {
+	dst := make([]byte, 20)
+	for i := range dst {
+		dst[i] = 0xee
+	}
+	if err := binaryID.PutBytes(dst); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(dst[:16], binaryBytes) || !bytes.Equal(dst[16:], []byte{0xee, 0xee, 0xee, 0xee}) {
+		t.Errorf("PutBytes wrote %x", dst)
+	}
+	if b := binaryID.Bytes(); !bytes.Equal(b[:], binaryBytes) {
+		t.Errorf("Bytes() = %x, want %x", b, binaryBytes)
+	}
+	if got := TraceIDFromBytes(binaryID.Bytes()); got != binaryID {
+		t.Errorf("TraceIDFromBytes(Bytes()) = %v, want %v", got, binaryID)
+	}
+	err := binaryID.PutBytes(make([]byte, 15))
+	if err == nil || !ErrShortBuffer.Equal(err) {
+		t.Fatalf("PutBytes of 15 bytes error = %v, want ErrShortBuffer", err)
+	}
+	if want := "cannot write a TraceID to 15 bytes, want 16"; err.Error() != want {
+		t.Errorf("error = %q, want %q", err, want)
+	}
+}
+
+func TestFromRawBytes(t *testing.T) {
+	var buf bytes.Buffer
+	for _, id := range []TraceID{binaryID, {Low: 1}, {High: ^uint64(0), Low: ^uint64(0)}, {}} {
+		dst := make([]byte, 16)
+		if err := id.PutBytes(dst); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := FromRawBytes(dst); err != nil || got != id {
+			t.Errorf("FromRawBytes(%x) = %v, %v, want %v", dst, got, err, id)
+		}
+		buf.Reset()
+		if _, err := id.WriteTo(&buf); err != nil || !bytes.Equal(buf.Bytes(), dst) {
+			t.Errorf("WriteTo wrote %x, %v, want %x", buf.Bytes(), err, dst)
+		}
+	}
+	if got, err := FromRawBytes(binaryBytes[:8]); err != nil || got != (TraceID{Low: 0x0102030405060708}) {
+		t.Errorf("FromRawBytes(%x) = %#v, %v, want Low only", binaryBytes[:8], got, err)
+	}
+	if _, err := FromRawBytes(binaryBytes[:12]); err == nil || !ErrBinaryLength.Equal(err) {
+		t.Errorf("FromRawBytes of 12 bytes error = %v, want ErrBinaryLength", err)
+	}
+}
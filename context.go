@@ -0,0 +1,72 @@
+package traceid
+
+import (
+	"context"
+)
+
+// contextKey, spanContextKey and sampledContextKey are unexported so no
+// other package can collide with them.
+type (
+	contextKey        struct{}
+	spanContextKey    struct{}
+	sampledContextKey struct{}
+)
+
+// NewContext returns a copy of ctx carrying id.
+func NewContext(ctx context.Context, id TraceID) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the TraceID stored in ctx by NewContext, and false if
+// there is none.
+func FromContext(ctx context.Context) (TraceID, bool) {
+	id, ok := ctx.Value(contextKey{}).(TraceID)
+	return id, ok
+}
+
+// MustFromContext is like FromContext but panics if ctx carries no TraceID.
+func MustFromContext(ctx context.Context) TraceID {
+	id, ok := FromContext(ctx)
+	if !ok {
+		panic("traceid: no TraceID in context, was it stored with NewContext?")
+	}
+	return id
+}
+
+// EnsureContext returns ctx and the TraceID it carries, or if there is none a
+// copy of ctx carrying a fresh id of gen, which is typically an
+// idgenerator.IDGenerator.
+func EnsureContext(ctx context.Context, gen interface{ TraceID() TraceID }) (context.Context, TraceID) {
+	if id, ok := FromContext(ctx); ok {
+		return ctx, id
+	}
+	id := gen.TraceID()
+	return NewContext(ctx, id), id
+}
+
+// NewSpanContext returns a copy of ctx carrying spanID as the id of the
+// current span, which propagators send as span or parent id on outgoing
+// requests.
+func NewSpanContext(ctx context.Context, spanID SpanID) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, spanID)
+}
+
+// SpanIDFromContext returns the SpanID stored in ctx by NewSpanContext, and
+// false if there is none.
+func SpanIDFromContext(ctx context.Context) (SpanID, bool) {
+	id, ok := ctx.Value(spanContextKey{}).(SpanID)
+	return id, ok
+}
+
+// NewSampledContext returns a copy of ctx carrying the sampling decision of
+// the trace, which propagators pass on instead of leaving it open.
+func NewSampledContext(ctx context.Context, sampled bool) context.Context {
+	return context.WithValue(ctx, sampledContextKey{}, sampled)
+}
+
+// SampledFromContext returns the sampling decision stored in ctx by
+// NewSampledContext, and ok false if the decision is open.
+func SampledFromContext(ctx context.Context) (sampled, ok bool) {
+	sampled, ok = ctx.Value(sampledContextKey{}).(bool)
+	return
+}
@@ -0,0 +1,93 @@
+package traceid
+
+import (
+	"context"
+	"testing"
+)
+
+// fixedGen hands out id and counts its calls.
+type fixedGen struct {
+	id    TraceID
+	calls int
+}
+
+func (g *fixedGen) TraceID() TraceID {
+	g.calls++
+	return g.id
+}
+
+func TestContext(t *testing.T) {
+	id := TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	root := context.Background()
+	ctx := NewContext(root, id)
+	if got, ok := FromContext(ctx); !ok || got != id {
+		t.Errorf("FromContext = %v, %v, want %v", got, ok, id)
+	}
+	type otherKey struct{}
+	child := context.WithValue(ctx, otherKey{}, "x")
+	if got, ok := FromContext(child); !ok || got != id {
+		t.Errorf("FromContext of a child = %v, %v, want %v", got, ok, id)
+	}
+	if _, ok := FromContext(root); ok {
+		t.Error("TraceID leaked into the parent context")
+	}
+	if _, ok := FromContext(context.WithValue(root, otherKey{}, id)); ok {
+		t.Error("TraceID read from a value under another key")
+	}
+	sibling := NewContext(root, TraceID{Low: 1})
+	if got, _ := FromContext(ctx); got != id {
+		t.Errorf("FromContext = %v after storing a sibling id, want %v", got, id)
+	}
+	if got, _ := FromContext(sibling); got != (TraceID{Low: 1}) {
+		t.Errorf("FromContext of the sibling = %v", got)
+	}
+	if got := MustFromContext(ctx); got != id {
+		t.Errorf("MustFromContext = %v, want %v", got, id)
+	}
+}
+
+func TestMustFromContextPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("MustFromContext of an empty context did not panic")
+		}
+	}()
+	MustFromContext(context.Background())
+}
+
+func TestEnsureContext(t *testing.T) {
+	id := TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	gen := &fixedGen{id: TraceID{Low: 0xab}}
+	ctx := NewContext(context.Background(), id)
+	got, gotID := EnsureContext(ctx, gen)
+	if got != ctx || gotID != id || gen.calls != 0 {
+		t.Errorf("EnsureContext with an id = %v, %d calls, want the context unchanged", gotID, gen.calls)
+	}
+	got, gotID = EnsureContext(context.Background(), gen)
+	if gotID != gen.id || gen.calls != 1 {
+		t.Errorf("EnsureContext without an id = %v, %d calls, want %v", gotID, gen.calls, gen.id)
+	}
+	if stored, ok := FromContext(got); !ok || stored != gen.id {
+		t.Errorf("EnsureContext stored %v, %v, want %v", stored, ok, gen.id)
+	}
+}
+
+func TestSpanAndSampledContext(t *testing.T) {
+	ctx := context.Background()
+	if _, ok := SpanIDFromContext(ctx); ok {
+		t.Error("SpanIDFromContext of an empty context reported a span id")
+	}
+	if _, ok := SampledFromContext(ctx); ok {
+		t.Error("SampledFromContext of an empty context reported a decision")
+	}
+	ctx = NewSampledContext(NewSpanContext(NewContext(ctx, TraceID{Low: 1}), 0x00f067aa0ba902b7), false)
+	if span, ok := SpanIDFromContext(ctx); !ok || span != 0x00f067aa0ba902b7 {
+		t.Errorf("SpanIDFromContext = %v, %v, want 00f067aa0ba902b7", span, ok)
+	}
+	if sampled, ok := SampledFromContext(ctx); !ok || sampled {
+		t.Errorf("SampledFromContext = %v, %v, want false, true", sampled, ok)
+	}
+	if id, ok := FromContext(ctx); !ok || id != (TraceID{Low: 1}) {
+		t.Errorf("FromContext = %v, %v, want the trace id kept", id, ok)
+	}
+}
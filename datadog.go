@@ -0,0 +1,66 @@
+package traceid
+
+import (
+	"strconv"
+
+	"github.com/kataras/iris/core/errors"
+)
+
+// DatadogTIDTag is the Datadog propagation tag carrying the upper 64 bits of
+// 128 bit trace ids as 16 hex characters.
+const DatadogTIDTag = "_dd.p.tid"
+
+// Datadog errors
+var (
+	ErrDatadogEmpty = errors.New("empty datadog trace id")
+	ErrDatadog      = errors.New("invalid datadog trace id %q, want an unsigned 64 bit decimal number")
+	ErrDatadogTID   = errors.New("invalid " + DatadogTIDTag + " %q, want 16 hex characters")
+)
+
+// DatadogString returns Low as unsigned decimal number, the form of the
+// x-datadog-trace-id header. High goes into the DatadogTIDTag, see DatadogTID.
+func (t TraceID) DatadogString() string {
+	return strconv.FormatUint(t.Low, 10)
+}
+
+// DatadogTID returns High as the 16 hex characters of the DatadogTIDTag, or
+// an empty string for a 64 bit TraceID which carries no tag.
+func (t TraceID) DatadogTID() string {
+	if t.High == 0 {
+		return ""
+	}
+	return t.String()[:16]
+}
+
+// ParseDatadog returns the TraceID with Low set from the decimal string of a
+// Datadog trace id. Leading zeros are accepted, signs and values overflowing
+// uint64 are not.
+func ParseDatadog(s string) (TraceID, error) {
+	if s == "" {
+		return TraceID{}, ErrDatadogEmpty
+	}
+	if !isDecimal(s) {
+		return TraceID{}, ErrDatadog.Format(s)
+	}
+	low, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return TraceID{}, ErrDatadog.Format(s)
+	}
+	return TraceID{Low: low}, nil
+}
+
+// ParseDatadog128 is ParseDatadog with High set from the value of the
+// DatadogTIDTag, which may be empty for a 64 bit trace id.
+func ParseDatadog128(s, tid string) (TraceID, error) {
+	t, err := ParseDatadog(s)
+	if err != nil || tid == "" {
+		return t, err
+	}
+	if len(tid) != 16 {
+		return TraceID{}, ErrDatadogTID.Format(tid)
+	}
+	if t.High, err = hexToUint64(tid, 0); err != nil {
+		return TraceID{}, ErrDatadogTID.Format(tid)
+	}
+	return t, nil
+}
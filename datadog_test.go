@@ -0,0 +1,59 @@
+package traceid
+
+import (
+	"testing"
+
+	"github.com/kataras/iris/core/errors"
+)
+
+func TestParseDatadog(t *testing.T) {
+	tests := []struct {
+		in   string
+		want TraceID
+		err  errors.Error
+	}{
+		{in: "11803532876627986230", want: TraceID{Low: 0xa3ce929d0e0e4736}},
+		{in: "0000000000171", want: TraceID{Low: 171}},
+		{in: "18446744073709551615", want: TraceID{Low: ^uint64(0)}},
+		{in: "0", want: TraceID{}},
+		{in: "", err: ErrDatadogEmpty},
+		{in: "18446744073709551616", err: ErrDatadog},
+		{in: "+1", err: ErrDatadog},
+		{in: "-1", err: ErrDatadog},
+		{in: "a3ce929d0e0e4736", err: ErrDatadog},
+	}
+	for _, tt := range tests {
+		got, err := ParseDatadog(tt.in)
+		if tt.err.NotEmpty() {
+			if err == nil || !tt.err.Equal(err) {
+				t.Errorf("ParseDatadog(%q) error = %v, want %v", tt.in, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseDatadog(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestDatadog128RoundTrip(t *testing.T) {
+	// the x-datadog-trace-id and _dd.p.tid of one 128 bit trace
+	id := TraceID{High: 0x640cfd8d00000000, Low: 0xa3ce929d0e0e4736}
+	if got, want := id.DatadogString(), "11803532876627986230"; got != want {
+		t.Errorf("DatadogString() = %q, want %q", got, want)
+	}
+	if got, want := id.DatadogTID(), "640cfd8d00000000"; got != want {
+		t.Errorf("DatadogTID() = %q, want %q", got, want)
+	}
+	if got, err := ParseDatadog128(id.DatadogString(), id.DatadogTID()); err != nil || got != id {
+		t.Errorf("ParseDatadog128 = %v, %v, want %v", got, err, id)
+	}
+	if got := (TraceID{Low: 1}).DatadogTID(); got != "" {
+		t.Errorf("DatadogTID() of a 64 bit id = %q, want empty", got)
+	}
+	for _, tid := range []string{"640cfd8d", "640cfd8d0000000z", "640cfd8d000000000"} {
+		if _, err := ParseDatadog128("1", tid); err == nil || !ErrDatadogTID.Equal(err) {
+			t.Errorf("ParseDatadog128 with tid %q error = %v, want ErrDatadogTID", tid, err)
+		}
+	}
+}
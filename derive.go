@@ -0,0 +1,67 @@
+package traceid
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// FromBytes128 derives a TraceID from data: High and Low are the first and
+// second 8 bytes, big-endian, of the SHA-256 digest of data. A zero result,
+// which SHA-256 is not known to produce, would be returned with Low set to 1.
+// This mapping is part of the API and will not change.
+func FromBytes128(data []byte) TraceID {
+	sum := sha256.Sum256(data)
+	t := TraceID{
+		High: binary.BigEndian.Uint64(sum[:8]),
+		Low:  binary.BigEndian.Uint64(sum[8:16]),
+	}
+	if t.IsZero() {
+		t.Low = 1
+	}
+	return t
+}
+
+// FromString derives a TraceID from the bytes of s, see FromBytes128.
+func FromString(s string) TraceID {
+	return FromBytes128([]byte(s))
+}
+
+// splitmix64Gamma is the increment of the splitmix64 generator.
+const splitmix64Gamma = 0x9e3779b97f4a7c15
+
+// splitmix64 is the bijective finalizer of the splitmix64 generator.
+func splitmix64(z uint64) uint64 {
+	z = (z ^ z>>30) * 0xbf58476d1ce4e5b9
+	z = (z ^ z>>27) * 0x94d049bb133111eb
+	return z ^ z>>31
+}
+
+// Derive returns the n-th child of id, computed with the splitmix64
+// finalizer mix and gamma 0x9e3779b97f4a7c15, all arithmetic modulo 2^64:
+//
+//	c    = gamma * (n + 1)
+//	High = mix(id.High + c)
+//	Low  = mix((id.Low ^ High) + c)
+//
+// For a fixed n this is a bijection on TraceIDs, so children of different
+// ids never collide. A zero result would be returned with Low set to 1. This
+// mapping is part of the API and will not change, other implementations can
+// check against Derive(TraceID{Low: 1}, 0) = e220a8397b1dcdaf08b4fda8c892b50e.
+func Derive(id TraceID, n uint64) TraceID {
+	c := splitmix64Gamma * (n + 1)
+	t := TraceID{High: splitmix64(id.High + c)}
+	t.Low = splitmix64((id.Low ^ t.High) + c)
+	if t.IsZero() {
+		t.Low = 1
+	}
+	return t
+}
+
+// DeriveSpanID returns the Low half of Derive(id, n) as span id, or 1 if it
+// is zero, for systems which have no room for span ids of their own.
+func DeriveSpanID(id TraceID, n uint64) SpanID {
+	if low := Derive(id, n).Low; low != 0 {
+		return SpanID(low)
+	}
+	return 1
+}
@@ -0,0 +1,67 @@
+package traceid
+
+import "testing"
+
+func TestFromBytes128Golden(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{"", "e3b0c44298fc1c149afbf4c8996fb924"},
+		{"abc", "ba7816bf8f01cfea414140de5dae2223"},
+		{"hello world", "b94d27b9934d3e08a52e52d7da7dabfa"},
+		{"request-42", "d4b46f2dab2d42d87163b87a55d5f434"},
+	}
+	for _, tt := range tests {
+		if got := FromBytes128([]byte(tt.data)).String(); got != tt.want {
+			t.Errorf("FromBytes128(%q) = %s, want %s", tt.data, got, tt.want)
+		}
+		if got := FromString(tt.data).String(); got != tt.want {
+			t.Errorf("FromString(%q) = %s, want %s", tt.data, got, tt.want)
+		}
+	}
+	if FromString("a") == FromString("b") {
+		t.Error(`FromString("a") == FromString("b")`)
+	}
+}
+
+func TestDeriveGolden(t *testing.T) {
+	w3c := TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	max := TraceID{High: ^uint64(0), Low: ^uint64(0)}
+	tests := []struct {
+		id   TraceID
+		n    uint64
+		want string
+		span string
+	}{
+		{TraceID{Low: 1}, 0, "e220a8397b1dcdaf08b4fda8c892b50e", "08b4fda8c892b50e"},
+		{TraceID{Low: 1}, 1, "6e789e6aa1b965f4f6b650ce10418d17", "f6b650ce10418d17"},
+		{TraceID{}, 0, "e220a8397b1dcdafa706dd2f4d197e6f", "a706dd2f4d197e6f"},
+		{w3c, 0, "d056a3e8328ce2fe06559f8c234d1224", "06559f8c234d1224"},
+		{w3c, 7, "286d38bea976a28cc6adcf9b83d9d98d", "c6adcf9b83d9d98d"},
+		{max, ^uint64(0), "b4d055fcf2cbbd7bd6bdf7544574c9cb", "d6bdf7544574c9cb"},
+	}
+	for _, tt := range tests {
+		if got := Derive(tt.id, tt.n).String(); got != tt.want {
+			t.Errorf("Derive(%v, %d) = %s, want %s", tt.id, tt.n, got, tt.want)
+		}
+		if got := DeriveSpanID(tt.id, tt.n).String(); got != tt.span {
+			t.Errorf("DeriveSpanID(%v, %d) = %s, want %s", tt.id, tt.n, got, tt.span)
+		}
+	}
+}
+
+func TestDeriveDistinct(t *testing.T) {
+	id := TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	seen := make(map[TraceID]uint64)
+	for n := uint64(0); n < 10000; n++ {
+		c := Derive(id, n)
+		if c.IsZero() {
+			t.Fatalf("Derive(%v, %d) is zero", id, n)
+		}
+		if prev, ok := seen[c]; ok {
+			t.Fatalf("Derive(%v, %d) = Derive(%v, %d) = %v", id, n, id, prev, c)
+		}
+		seen[c] = n
+	}
+}
@@ -0,0 +1,44 @@
+package traceid
+
+import (
+	"flag"
+
+	"github.com/kataras/iris/core/errors"
+)
+
+// ErrFlagValue is returned by Flag.Set for input Parse rejects. The flag
+// package prefixes it with the offending value and the flag name.
+var ErrFlagValue = errors.New("want a trace id as hex of up to 32 characters, a UUID or a decimal number: %v")
+
+// Flag is a TraceID implementing flag.Value and flag.Getter, accepting the
+// formats of Parse.
+type Flag TraceID
+
+// String returns the hex representation of the TraceID.
+func (f *Flag) String() string {
+	if f == nil {
+		return ""
+	}
+	return TraceID(*f).String()
+}
+
+// Set implements flag.Value.
+func (f *Flag) Set(s string) error {
+	t, err := Parse(s)
+	if err != nil {
+		return ErrFlagValue.Format(err)
+	}
+	*f = Flag(t)
+	return nil
+}
+
+// Get implements flag.Getter, returning the TraceID.
+func (f *Flag) Get() interface{} {
+	return TraceID(*f)
+}
+
+// FlagVar defines a TraceID flag with the given name and usage on fs, storing
+// its value in p.
+func FlagVar(fs *flag.FlagSet, p *TraceID, name, usage string) {
+	fs.Var((*Flag)(p), name, usage)
+}
@@ -0,0 +1,57 @@
+package traceid
+
+import (
+	"flag"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestFlagVar(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var id TraceID
+	FlagVar(fs, &id, "trace", "trace id")
+	if err := fs.Parse([]string{"-trace", "4bf92f3577b34da6a3ce929d0e0e4736"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := (TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}); id != want {
+		t.Errorf("id = %v, want %v", id, want)
+	}
+	if got := fs.Lookup("trace").Value.(flag.Getter).Get(); got != id {
+		t.Errorf("Get() = %v, want %v", got, id)
+	}
+	if got := fs.Lookup("trace").Value.String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("String() = %q", got)
+	}
+	for in, want := range map[string]TraceID{
+		"4bf92f35-77b3-4da6-a3ce-929d0e0e4736": {High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736},
+		"12345":                                {Low: 12345},
+		"abc":                                  {Low: 0xabc},
+	} {
+		if err := fs.Set("trace", in); err != nil || id != want {
+			t.Errorf("Set(%q) = %v, %v, want %v", in, id, err, want)
+		}
+	}
+	if got := (*Flag)(nil).String(); got != "" {
+		t.Errorf("nil String() = %q, want empty", got)
+	}
+}
+
+func TestFlagVarInvalid(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	id := TraceID{Low: 1}
+	FlagVar(fs, &id, "trace", "trace id")
+	err := fs.Parse([]string{"-trace", "not-a-trace-id"})
+	if err == nil {
+		t.Fatal("Parse succeeded, want an error")
+	}
+	for _, want := range []string{"-trace", "not-a-trace-id", "hex", "UUID", "decimal"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+	if id != (TraceID{Low: 1}) {
+		t.Errorf("id changed to %v on error", id)
+	}
+}
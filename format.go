@@ -0,0 +1,65 @@
+package traceid
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Format implements fmt.Formatter:
+//
+//	%s, %v  the hex representation of String
+//	%x, %X  32 lower or upper case hex characters, the short form of String
+//	        with the '#' flag
+//	%d      the decimal halves as high:low
+//	%q      String in double quotes
+//	%#v     Go syntax
+//
+// A width pads with spaces on the left, or on the right with the '-' flag.
+// The '0' flag is ignored, hex output is already zero padded.
+func (t TraceID) Format(f fmt.State, verb rune) {
+	var s string
+	switch verb {
+	case 'v':
+		if f.Flag('#') {
+			s = fmt.Sprintf("traceid.TraceID{High:0x%x, Low:0x%x}", t.High, t.Low)
+			break
+		}
+		s = t.String()
+	case 's':
+		s = t.String()
+	case 'x', 'X':
+		if f.Flag('#') {
+			s = t.String()
+		} else {
+			s = fmt.Sprintf("%016x%016x", t.High, t.Low)
+		}
+		if verb == 'X' {
+			s = strings.ToUpper(s)
+		}
+	case 'd':
+		s = strconv.FormatUint(t.High, 10) + ":" + strconv.FormatUint(t.Low, 10)
+	case 'q':
+		s = strconv.Quote(t.String())
+	default:
+		fmt.Fprintf(f, "%%!%c(traceid.TraceID=%s)", verb, t.String())
+		return
+	}
+	writePadded(f, s)
+}
+
+// writePadded writes s to f honoring its width and '-' flag.
+func writePadded(f fmt.State, s string) {
+	w, ok := f.Width()
+	if !ok || w <= len(s) {
+		f.Write([]byte(s))
+		return
+	}
+	pad := strings.Repeat(" ", w-len(s))
+	if f.Flag('-') {
+		s += pad
+	} else {
+		s = pad + s
+	}
+	f.Write([]byte(s))
+}
@@ -0,0 +1,41 @@
+package traceid
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	id := TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	short := TraceID{Low: 0xab}
+	tests := []struct {
+		format string
+		id     TraceID
+		want   string
+	}{
+		{"%s", id, "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"%v", id, "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"%s", short, "00000000000000ab"},
+		{"%x", short, "000000000000000000000000000000ab"},
+		{"%#x", short, "00000000000000ab"},
+		{"%X", id, "4BF92F3577B34DA6A3CE929D0E0E4736"},
+		{"%#X", short, "00000000000000AB"},
+		{"%d", id, "5474458728733560230:11803532876627986230"},
+		{"%d", short, "0:171"},
+		{"%q", short, `"00000000000000ab"`},
+		{"%#v", short, "traceid.TraceID{High:0x0, Low:0xab}"},
+		{"%20s", short, "    00000000000000ab"},
+		{"%-20s|", short, "00000000000000ab    |"},
+		{"%020s", short, "    00000000000000ab"},
+		{"%4s", short, "00000000000000ab"},
+		{"%t", short, "%!t(traceid.TraceID=00000000000000ab)"},
+	}
+	for _, tt := range tests {
+		if got := fmt.Sprintf(tt.format, tt.id); got != tt.want {
+			t.Errorf("Sprintf(%q, %#v) = %q, want %q", tt.format, tt.id, got, tt.want)
+		}
+	}
+	if got, want := fmt.Sprintf("%v", []TraceID{short}), "[00000000000000ab]"; got != want {
+		t.Errorf("Sprintf(%%v, slice) = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,25 @@
+//go:build go1.18
+// +build go1.18
+
+package traceid
+
+import "testing"
+
+func FuzzParseHex(f *testing.F) {
+	f.Add("4bf92f3577b34da6a3ce929d0e0e4736", uint64(0), uint64(1))
+	f.Add("ab", uint64(1), ^uint64(0))
+	f.Add("", ^uint64(0), uint64(0))
+	f.Fuzz(func(t *testing.T, s string, high, low uint64) {
+		id := TraceID{High: high, Low: low}
+		if got, err := ParseHex(id.String()); err != nil || got != id {
+			t.Fatalf("ParseHex(%q) = %#v, %v, want %#v", id.String(), got, err, id)
+		}
+		parsed, err := ParseHex(s)
+		if err != nil {
+			return
+		}
+		if got, err := ParseHex(parsed.String()); err != nil || got != parsed {
+			t.Fatalf("ParseHex(%q) = %#v, %v, want %#v", parsed.String(), got, err, parsed)
+		}
+	})
+}
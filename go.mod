@@ -2,4 +2,11 @@ module github.com/ximply/traceid
 
 go 1.12
 
-require github.com/kataras/iris v11.1.1+incompatible
+require (
+	github.com/kataras/iris v11.1.1+incompatible
+	github.com/opentracing/opentracing-go v1.1.0
+	github.com/openzipkin/zipkin-go v0.2.2
+	github.com/prometheus/client_golang v1.5.1
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
+	google.golang.org/grpc v1.27.1
+)
@@ -0,0 +1,39 @@
+/*
+Package grpc propagates trace ids in gRPC metadata. It lives in a package of
+its own so that users of traceid who do not use gRPC do not depend on it.
+*/
+package grpc
+
+import (
+	"context"
+
+	"github.com/ximply/traceid"
+	"google.golang.org/grpc/metadata"
+)
+
+// TraceIDKey is the metadata key the trace id travels in, the lowercased B3
+// trace id header.
+const TraceIDKey = "x-b3-traceid"
+
+// InjectMetadata returns a copy of ctx whose outgoing metadata carries id.
+func InjectMetadata(ctx context.Context, id traceid.TraceID) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, TraceIDKey, id.String())
+}
+
+// ExtractMetadata returns the trace id carried by the incoming metadata of
+// ctx, and false if there is none or it is not a valid trace id.
+func ExtractMetadata(ctx context.Context) (traceid.TraceID, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return traceid.TraceID{}, false
+	}
+	vals := md.Get(TraceIDKey)
+	if len(vals) == 0 {
+		return traceid.TraceID{}, false
+	}
+	id, err := traceid.TraceIDFromHex(vals[0])
+	if err != nil || id.IsZero() {
+		return traceid.TraceID{}, false
+	}
+	return id, true
+}
@@ -0,0 +1,59 @@
+/*
+Package http provides net/http middleware making sure every request carries a
+trace id. Import it under another name to keep net/http usable:
+
+	import traceidhttp "github.com/ximply/traceid/http"
+*/
+package http
+
+import (
+	"net/http"
+
+	"github.com/ximply/traceid"
+	"github.com/ximply/traceid/idgenerator"
+	"github.com/ximply/traceid/propagation"
+)
+
+// Middleware returns a handler which extracts the trace id of a request with
+// propagator, generates one with gen if there is none, stores it in the
+// request context and writes it to the response headers, with the span id and
+// sampling decision of the request context, before calling next.
+func Middleware(gen idgenerator.IDGenerator, propagator propagation.Propagator, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := propagator.Extract(r.Header)
+		if err != nil || id.IsZero() {
+			id = gen.TraceID()
+		}
+		propagation.InjectContext(r.Context(), propagator, id, w.Header())
+		next.ServeHTTP(w, r.WithContext(traceid.NewContext(r.Context(), id)))
+	})
+}
+
+// Ensure returns middleware which stores a trace id in the context of every
+// request: the inbound one if the request carries a valid one in the formats
+// of WithExtract, otherwise a fresh one of gen. Malformed inbound ids are
+// replaced, never passed on. The id is echoed in the response header of
+// WithResponseHeader.
+func Ensure(gen idgenerator.IDGenerator, opts ...Option) func(http.Handler) http.Handler {
+	c := newConfig(opts)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var id traceid.TraceID
+			if c.trust {
+				id, _ = c.extract.Extract(r.Header)
+			}
+			if id.IsZero() {
+				id = gen.TraceID()
+			}
+			if c.response != "" {
+				w.Header().Set(c.response, id.String())
+			}
+			next.ServeHTTP(w, r.WithContext(traceid.NewContext(r.Context(), id)))
+		})
+	}
+}
+
+// TraceIDFromRequest returns the trace id Middleware or Ensure stored for r.
+func TraceIDFromRequest(r *http.Request) (traceid.TraceID, bool) {
+	return traceid.FromContext(r.Context())
+}
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ximply/traceid"
+	"github.com/ximply/traceid/idgenerator"
+	"github.com/ximply/traceid/propagation"
+)
+
+var (
+	inboundID   = traceid.TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	generatedID = traceid.TraceID{Low: 0xab}
+)
+
+// serve runs r through handler and returns the response and the trace id the
+// handler saw.
+func serve(t *testing.T, handler func(http.Handler) http.Handler, r *http.Request) (*httptest.ResponseRecorder, traceid.TraceID) {
+	t.Helper()
+	var got traceid.TraceID
+	h := handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ok bool
+		if got, ok = TraceIDFromRequest(r); !ok {
+			t.Error("no trace id in the request context")
+		}
+	}))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w, got
+}
+
+func TestEnsure(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		headers map[string]string
+		want    traceid.TraceID
+	}{
+		{"absent", nil, nil, generatedID},
+		{"b3", nil, map[string]string{"X-B3-TraceId": inboundID.String(), "X-B3-SpanId": "00f067aa0ba902b7"}, inboundID},
+		{"traceparent", nil, map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}, inboundID},
+		{"garbage b3", nil, map[string]string{"X-B3-TraceId": "garbage", "X-B3-SpanId": "00f067aa0ba902b7"}, generatedID},
+		{"garbage traceparent", nil, map[string]string{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-garbage"}, generatedID},
+		{"zero", nil, map[string]string{"traceparent": "00-00000000000000000000000000000000-00f067aa0ba902b7-01"}, generatedID},
+		{"without trust", []Option{WithoutTrust()}, map[string]string{"X-B3-TraceId": inboundID.String(), "X-B3-SpanId": "00f067aa0ba902b7"}, generatedID},
+		{"extract", []Option{WithExtract(propagation.W3CPropagator{})}, map[string]string{"X-B3-TraceId": inboundID.String(), "X-B3-SpanId": "00f067aa0ba902b7"}, generatedID},
+		{"request id", []Option{WithRequestIDHeader(propagation.RequestIDHeader)}, map[string]string{"X-Request-Id": inboundID.String()}, inboundID},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		for k, v := range tt.headers {
+			r.Header.Set(k, v)
+		}
+		w, got := serve(t, Ensure(idgenerator.NewFixed(generatedID), tt.opts...), r)
+		if got != tt.want {
+			t.Errorf("%s: trace id = %v, want %v", tt.name, got, tt.want)
+		}
+		if h := w.Header().Get(propagation.B3TraceIDHeader); h != tt.want.String() {
+			t.Errorf("%s: response header = %q, want %q", tt.name, h, tt.want.String())
+		}
+	}
+}
+
+func TestEnsureResponseHeader(t *testing.T) {
+	gen := idgenerator.NewFixed(generatedID)
+	w, _ := serve(t, Ensure(gen, WithResponseHeader(propagation.RequestIDHeader)), httptest.NewRequest("GET", "/", nil))
+	if got := w.Header().Get(propagation.RequestIDHeader); got != generatedID.String() {
+		t.Errorf("%s = %q, want %q", propagation.RequestIDHeader, got, generatedID.String())
+	}
+	if got := w.Header().Get(propagation.B3TraceIDHeader); got != "" {
+		t.Errorf("%s = %q, want it unset", propagation.B3TraceIDHeader, got)
+	}
+	w, _ = serve(t, Ensure(gen, WithResponseHeader("")), httptest.NewRequest("GET", "/", nil))
+	if len(w.Header()) != 0 {
+		t.Errorf("response headers = %v, want none", w.Header())
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	gen := idgenerator.NewFixed(generatedID)
+	middleware := func(next http.Handler) http.Handler {
+		return Middleware(gen, propagation.W3CPropagator{}, next)
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set(propagation.TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	w, got := serve(t, middleware, r)
+	if got != inboundID {
+		t.Errorf("trace id = %v, want %v", got, inboundID)
+	}
+	if id, err := (propagation.W3CPropagator{}).Extract(w.Header()); err != nil || id != inboundID {
+		t.Errorf("response traceparent = %v, %v, want %v", id, err, inboundID)
+	}
+
+	r = httptest.NewRequest("GET", "/", nil)
+	r.Header.Set(propagation.TraceparentHeader, "garbage")
+	if _, got := serve(t, middleware, r); got != generatedID {
+		t.Errorf("trace id = %v, want %v", got, generatedID)
+	}
+}
@@ -0,0 +1,77 @@
+package http
+
+import (
+	"github.com/ximply/traceid/idgenerator"
+	"github.com/ximply/traceid/propagation"
+)
+
+// Option configures Ensure and NewTransport.
+type Option func(*config)
+
+type config struct {
+	extract  propagation.Propagator
+	response string
+	trust    bool
+	inject   propagation.Propagator
+	gen      idgenerator.IDGenerator
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		extract:  propagation.NewMultiPropagator(propagation.B3Propagator{}, propagation.W3CPropagator{}),
+		response: propagation.B3TraceIDHeader,
+		trust:    true,
+		inject:   propagation.B3Propagator{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithExtract sets the formats inbound trace ids are read in, tried in
+// order. The default is the B3 multi header form followed by traceparent.
+func WithExtract(ps ...propagation.Propagator) Option {
+	return func(c *config) {
+		c.extract = propagation.NewMultiPropagator(ps...)
+	}
+}
+
+// WithRequestIDHeader reads inbound trace ids from the plain header name, such
+// as propagation.RequestIDHeader, instead of the default formats.
+func WithRequestIDHeader(name string) Option {
+	return WithExtract(propagation.HeaderPropagator{Header: name})
+}
+
+// WithResponseHeader sets the header echoing the trace id on the response,
+// X-B3-TraceId by default. An empty name leaves the response alone.
+func WithResponseHeader(name string) Option {
+	return func(c *config) {
+		c.response = name
+	}
+}
+
+// WithoutTrust ignores inbound trace ids and generates a fresh one for every
+// request, for services at the edge of the network.
+func WithoutTrust() Option {
+	return func(c *config) {
+		c.trust = false
+	}
+}
+
+// WithInject sets the formats NewTransport writes trace ids in, such as
+// propagation.B3Propagator with or without SingleHeader and
+// propagation.W3CPropagator. The default is the B3 multi header form.
+func WithInject(ps ...propagation.Propagator) Option {
+	return func(c *config) {
+		c.inject = propagation.NewMultiPropagator(ps...)
+	}
+}
+
+// WithGenerator makes NewTransport generate a trace id with gen for requests
+// whose context carries none. Without it such requests are sent unchanged.
+func WithGenerator(gen idgenerator.IDGenerator) Option {
+	return func(c *config) {
+		c.gen = gen
+	}
+}
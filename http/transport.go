@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/ximply/traceid"
+	"github.com/ximply/traceid/propagation"
+)
+
+// NewTransport returns an http.RoundTripper which writes the trace id stored
+// in the request context to the headers of outgoing requests in the formats
+// of WithInject before passing them to base, http.DefaultTransport if nil.
+// Span id and sampling decision come from the request context as well, see
+// propagation.ContextInjector. The caller's request is not modified, the
+// headers are sent on a copy.
+func NewTransport(base http.RoundTripper, opts ...Option) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base, config: newConfig(opts)}
+}
+
+type transport struct {
+	base http.RoundTripper
+	*config
+}
+
+func (t *transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	id, ok := traceid.FromContext(r.Context())
+	if !ok && t.gen != nil {
+		id = t.gen.TraceID()
+	}
+	if id.IsZero() {
+		return t.base.RoundTrip(r)
+	}
+	out := new(http.Request)
+	*out = *r
+	out.Header = make(http.Header, len(r.Header)+3)
+	for k, v := range r.Header {
+		out.Header[k] = append([]string(nil), v...)
+	}
+	propagation.InjectContext(r.Context(), t.inject, id, out.Header)
+	return t.base.RoundTrip(out)
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ximply/traceid"
+	"github.com/ximply/traceid/idgenerator"
+	"github.com/ximply/traceid/propagation"
+)
+
+// roundTripFunc is a stub RoundTripper recording the requests it is sent.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// send sends r through NewTransport with opts and returns the request the
+// base transport saw.
+func send(t *testing.T, r *http.Request, opts ...Option) *http.Request {
+	t.Helper()
+	var sent *http.Request
+	base := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		sent = r
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: r}, nil
+	})
+	if _, err := NewTransport(base, opts...).RoundTrip(r); err != nil {
+		t.Fatal(err)
+	}
+	return sent
+}
+
+func TestTransport(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://example.com/", nil)
+	r.Header.Set("Accept", "text/plain")
+	r = r.WithContext(traceid.NewContext(r.Context(), inboundID))
+
+	sent := send(t, r)
+	if got := sent.Header.Get(propagation.B3TraceIDHeader); got != inboundID.String() {
+		t.Errorf("%s = %q, want %q", propagation.B3TraceIDHeader, got, inboundID.String())
+	}
+	if got := sent.Header.Get("Accept"); got != "text/plain" {
+		t.Errorf("Accept = %q, want it copied", got)
+	}
+	if len(r.Header) != 1 || r.Header.Get(propagation.B3TraceIDHeader) != "" {
+		t.Errorf("caller headers modified: %v", r.Header)
+	}
+	sent.Header.Add("Accept", "text/html")
+	if got := r.Header["Accept"]; len(got) != 1 {
+		t.Errorf("caller header values share storage with the sent request: %v", got)
+	}
+}
+
+func TestTransportFormats(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://example.com/", nil)
+	ctx := traceid.NewSpanContext(traceid.NewContext(r.Context(), inboundID), 0x00f067aa0ba902b7)
+	r = r.WithContext(traceid.NewSampledContext(ctx, true))
+	sent := send(t, r, WithInject(propagation.B3Propagator{SingleHeader: true}, propagation.W3CPropagator{}))
+	if got, want := sent.Header.Get(propagation.B3SingleHeader), "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1"; got != want {
+		t.Errorf("b3 = %q, want %q", got, want)
+	}
+	if got, want := sent.Header.Get(propagation.TraceparentHeader), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"; got != want {
+		t.Errorf("traceparent = %q, want %q", got, want)
+	}
+	if got := sent.Header.Get(propagation.B3TraceIDHeader); got != "" {
+		t.Errorf("%s = %q, want it unset", propagation.B3TraceIDHeader, got)
+	}
+}
+
+func TestTransportWithoutSpan(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://example.com/", nil)
+	r = r.WithContext(traceid.NewContext(r.Context(), inboundID))
+	sent := send(t, r)
+	if got := sent.Header.Get(propagation.B3SpanIDHeader); got == "" || got == "a3ce929d0e0e4736" {
+		t.Errorf("%s = %q, want a fresh span id", propagation.B3SpanIDHeader, got)
+	}
+	if got := sent.Header.Get(propagation.B3SampledHeader); got != "" {
+		t.Errorf("%s = %q, want the decision left open", propagation.B3SampledHeader, got)
+	}
+}
+
+func TestTransportWithoutID(t *testing.T) {
+	r := httptest.NewRequest("GET", "http://example.com/", nil)
+	if sent := send(t, r); sent != r || len(sent.Header) != 0 {
+		t.Errorf("request without trace id changed: %v", sent.Header)
+	}
+	sent := send(t, r, WithGenerator(idgenerator.NewFixed(generatedID)))
+	if got := sent.Header.Get(propagation.B3TraceIDHeader); got != generatedID.String() {
+		t.Errorf("%s = %q, want %q", propagation.B3TraceIDHeader, got, generatedID.String())
+	}
+	if len(r.Header) != 0 {
+		t.Errorf("caller headers modified: %v", r.Header)
+	}
+}
@@ -0,0 +1,52 @@
+package idgenerator
+
+import (
+	"github.com/kataras/iris/core/errors"
+	"github.com/ximply/traceid"
+)
+
+// DefaultTagBits is the tag width used by NewAuthenticated.
+const DefaultTagBits = 16
+
+// ErrTagBits is returned for tag widths outside [1, 64].
+var ErrTagBits = errors.New("tag width %d out of range [1, 64]")
+
+// NewAuthenticated returns an ID Generator which takes ids from inner and
+// replaces the low 16 bits of Low with an HMAC-SHA256 tag under key, see
+// traceid.Authenticate. traceid.VerifyAuthenticated tells ids minted this way
+// apart from fabricated ones.
+func NewAuthenticated(key []byte, inner IDGenerator) IDGenerator {
+	gen, err := NewAuthenticatedBits(key, DefaultTagBits, inner)
+	if err != nil {
+		panic("idgenerator: NewAuthenticated: " + err.Error())
+	}
+	return gen
+}
+
+// NewAuthenticatedBits is like NewAuthenticated with a tag of bits bits. It
+// returns ErrTagBits for widths outside [1, 64].
+func NewAuthenticatedBits(key []byte, bits uint, inner IDGenerator) (IDGenerator, error) {
+	if bits < 1 || bits > 64 {
+		return nil, ErrTagBits.Format(bits)
+	}
+	return &authenticated{
+		inner: inner,
+		key:   append([]byte(nil), key...),
+		bits:  bits,
+	}, nil
+}
+
+// authenticated tags the traceid's of another generator.
+type authenticated struct {
+	inner IDGenerator
+	key   []byte
+	bits  uint
+}
+
+// TraceID draws from inner again whenever tagging leaves Low zero.
+func (a *authenticated) TraceID() (id traceid.TraceID) {
+	for id.Low == 0 {
+		id = traceid.Authenticate(a.inner.TraceID(), a.key, a.bits)
+	}
+	return
+}
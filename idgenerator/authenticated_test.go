@@ -0,0 +1,48 @@
+package idgenerator
+
+import (
+	"testing"
+
+	"github.com/ximply/traceid"
+)
+
+func TestAuthenticated(t *testing.T) {
+	key := []byte("edge secret")
+	for _, bits := range []uint{1, 16, 64} {
+		gen, err := NewAuthenticatedBits(key, bits, NewRandom128())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 1000; i++ {
+			id := gen.TraceID()
+			if id.Low == 0 {
+				t.Fatalf("bits %d: zero Low", bits)
+			}
+			if !traceid.VerifyAuthenticated(id, key, bits) {
+				t.Fatalf("bits %d: %v does not verify", bits, id)
+			}
+		}
+	}
+	for _, bits := range []uint{0, 65} {
+		if _, err := NewAuthenticatedBits(key, bits, NewRandom128()); err == nil || !ErrTagBits.Equal(err) {
+			t.Errorf("NewAuthenticatedBits(%d) error = %v, want ErrTagBits", bits, err)
+		}
+	}
+}
+
+func TestAuthenticatedRedrawsZeroLow(t *testing.T) {
+	key := []byte("edge secret")
+	// find an id with Low 1 whose 1 bit tag is zero, leaving Low zero
+	zero := traceid.TraceID{Low: 1}
+	for traceid.Authenticate(zero, key, 1).Low != 0 {
+		zero.High++
+	}
+	next := traceid.TraceID{Low: 0xab}
+	gen, err := NewAuthenticatedBits(key, 1, NewFixedSequence(false, zero, next))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := gen.TraceID(), traceid.Authenticate(next, key, 1); got != want {
+		t.Errorf("got %v, want the tagged next id %v", got, want)
+	}
+}
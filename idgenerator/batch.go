@@ -0,0 +1,102 @@
+package idgenerator
+
+import (
+	"github.com/ximply/traceid"
+)
+
+// BatchGenerator is implemented by generators which can hand out many Trace
+// IDs while taking their lock only once. The generators returned by
+// NewRandom64, NewRandom128 and NewRandomTimestamped implement it, as well as
+// BatchIDGenerator.
+type BatchGenerator interface {
+	IDGenerator
+	// TraceIDs returns n new Trace IDs.
+	TraceIDs(n int) []traceid.TraceID
+	// AppendTraceIDs appends n new Trace IDs to dst and returns the result.
+	AppendTraceIDs(dst []traceid.TraceID, n int) []traceid.TraceID
+}
+
+func (r *randomID64) TraceIDs(n int) []traceid.TraceID {
+	return r.AppendTraceIDs(makeIDs(n), n)
+}
+
+func (r *randomID64) AppendTraceIDs(dst []traceid.TraceID, n int) []traceid.TraceID {
+	return r.appendIDs(dst, n, r.traceID)
+}
+
+func (r *randomID128) TraceIDs(n int) []traceid.TraceID {
+	return r.AppendTraceIDs(makeIDs(n), n)
+}
+
+func (r *randomID128) AppendTraceIDs(dst []traceid.TraceID, n int) []traceid.TraceID {
+	return r.appendIDs(dst, n, r.traceID)
+}
+
+// TraceIDs reads the clock for every id, so a batch sorts the same way as a
+// loop of TraceID calls would.
+func (t *randomTimestamped) TraceIDs(n int) []traceid.TraceID {
+	return t.AppendTraceIDs(makeIDs(n), n)
+}
+
+func (t *randomTimestamped) AppendTraceIDs(dst []traceid.TraceID, n int) []traceid.TraceID {
+	return t.appendIDs(dst, n, t.traceID)
+}
+
+func makeIDs(n int) []traceid.TraceID {
+	if n < 0 {
+		n = 0
+	}
+	return make([]traceid.TraceID, 0, n)
+}
+
+// appendIDs appends n results of next to dst under a single lock.
+func (l *lockedRand) appendIDs(dst []traceid.TraceID, n int, next func() traceid.TraceID) []traceid.TraceID {
+	l.lock()
+	for i := 0; i < n; i++ {
+		dst = append(dst, next())
+	}
+	l.unlock()
+	return dst
+}
+
+// BatchIDGenerator hands out Trace IDs in bulk under a single lock.
+type BatchIDGenerator interface {
+	IDGenerator
+	// TraceIDBatch returns n new Trace IDs.
+	TraceIDBatch(n int) []traceid.TraceID
+}
+
+// NewBatchRandom128 returns a 128 bit BatchIDGenerator for callers which
+// usually need batchHint ids at a time: TraceIDBatch with a non-positive n
+// returns batchHint ids.
+func NewBatchRandom128(batchHint int, opts ...Option) BatchIDGenerator {
+	return &batchRandom128{
+		randomID128: randomID128{lockedRand: newLockedRand(newConfig(opts))},
+		hint:        batchHint,
+	}
+}
+
+// batchRandom128 is a randomID128 with a default batch size.
+type batchRandom128 struct {
+	randomID128
+	hint int
+}
+
+func (b *batchRandom128) TraceIDBatch(n int) []traceid.TraceID {
+	if n <= 0 {
+		n = b.hint
+	}
+	return b.TraceIDs(n)
+}
+
+func (r *randomID64) TraceIDBatch(n int) []traceid.TraceID {
+	return r.TraceIDs(n)
+}
+
+func (r *randomID128) TraceIDBatch(n int) []traceid.TraceID {
+	return r.TraceIDs(n)
+}
+
+func (t *randomTimestamped) TraceIDBatch(n int) []traceid.TraceID {
+	return t.TraceIDs(n)
+}
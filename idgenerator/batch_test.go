@@ -0,0 +1,103 @@
+package idgenerator
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ximply/traceid"
+)
+
+func TestTraceIDs(t *testing.T) {
+	for name, gen := range map[string]BatchGenerator{
+		"Random64":          NewRandom64().(BatchGenerator),
+		"Random128":         NewRandom128().(BatchGenerator),
+		"RandomTimestamped": NewRandomTimestamped().(BatchGenerator),
+	} {
+		for _, n := range []int{-1, 0, 1, 100} {
+			ids := gen.TraceIDs(n)
+			want := n
+			if want < 0 {
+				want = 0
+			}
+			if len(ids) != want {
+				t.Errorf("%s: TraceIDs(%d) returned %d ids", name, n, len(ids))
+			}
+			seen := make(map[traceid.TraceID]bool)
+			for _, id := range ids {
+				if id.Low == 0 || seen[id] {
+					t.Errorf("%s: TraceIDs(%d) returned %v twice or with a zero Low", name, n, id)
+				}
+				seen[id] = true
+			}
+		}
+	}
+}
+
+func TestAppendTraceIDs(t *testing.T) {
+	gen := NewRandom128().(BatchGenerator)
+	first := traceid.TraceID{Low: 1}
+	buf := make([]traceid.TraceID, 1, 64)
+	buf[0] = first
+	ids := gen.AppendTraceIDs(buf, 10)
+	if len(ids) != 11 || ids[0] != first {
+		t.Fatalf("AppendTraceIDs = %v, want the prefix kept and 10 ids appended", ids)
+	}
+	if &ids[0] != &buf[0] {
+		t.Error("AppendTraceIDs reallocated a buffer with enough capacity")
+	}
+	reused := gen.AppendTraceIDs(ids[:0], 10)
+	if len(reused) != 10 || &reused[0] != &buf[0] {
+		t.Error("AppendTraceIDs did not reuse the truncated buffer")
+	}
+}
+
+func TestTimestampedBatchSorted(t *testing.T) {
+	// every read of the clock is a second later than the one before
+	var mtx sync.Mutex
+	now := time.Date(2024, 5, 17, 13, 37, 42, 0, time.UTC)
+	clock := func() time.Time {
+		mtx.Lock()
+		defer mtx.Unlock()
+		now = now.Add(time.Second)
+		return now
+	}
+	ids := NewRandomTimestamped(WithClock(clock)).(BatchGenerator).TraceIDs(100)
+	for i := 1; i < len(ids); i++ {
+		if !ids[i-1].Less(ids[i]) {
+			t.Fatalf("id %d = %v does not sort after %v", i, ids[i], ids[i-1])
+		}
+	}
+}
+
+func TestTraceIDBatch(t *testing.T) {
+	gen := NewBatchRandom128(16)
+	if got := len(gen.TraceIDBatch(0)); got != 16 {
+		t.Errorf("TraceIDBatch(0) returned %d ids, want the hint 16", got)
+	}
+	if got := len(gen.TraceIDBatch(5)); got != 5 {
+		t.Errorf("TraceIDBatch(5) returned %d ids", got)
+	}
+}
+
+// b.N counts ids, so both benchmarks report the cost per id.
+func BenchmarkTraceIDs(b *testing.B) {
+	const batch = 64
+	gen := NewRandom128WithSeed(1).(BatchGenerator)
+	buf := make([]traceid.TraceID, 0, batch)
+	for i := 0; i < b.N; i += batch {
+		buf = gen.AppendTraceIDs(buf[:0], batch)
+	}
+}
+
+func BenchmarkTraceIDLoop(b *testing.B) {
+	const batch = 64
+	gen := NewRandom128WithSeed(1)
+	buf := make([]traceid.TraceID, 0, batch)
+	for i := 0; i < b.N; i += batch {
+		buf = buf[:0]
+		for j := 0; j < batch; j++ {
+			buf = append(buf, gen.TraceID())
+		}
+	}
+}
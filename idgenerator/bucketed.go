@@ -0,0 +1,59 @@
+package idgenerator
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ximply/traceid"
+)
+
+// NewTimeBucketed returns an ID Generator which returns the same id for all
+// calls within a window and a fresh id of base once the next window started.
+// Windows are aligned to multiples of window since the unix epoch, so a five
+// minute window starts at :00, :05 and so on. Within a window it is lock
+// free, moving to the next window takes a lock so callers racing at a
+// boundary draw a single id of base and all return it. The WithClock option
+// is honored. It panics if window is not positive.
+func NewTimeBucketed(base IDGenerator, window time.Duration, opts ...Option) IDGenerator {
+	if window <= 0 {
+		panic("idgenerator: NewTimeBucketed needs a positive window")
+	}
+	return &timeBucketed{base: base, window: int64(window), clock: newConfig(opts).clock}
+}
+
+type timeBucketed struct {
+	current atomic.Value // *bucket
+	mtx     sync.Mutex   // serializes rotations
+	base    IDGenerator
+	window  int64
+	clock   func() time.Time
+}
+
+// bucket is the id of the window with index n.
+type bucket struct {
+	n  int64
+	id traceid.TraceID
+}
+
+func (t *timeBucketed) TraceID() traceid.TraceID {
+	n := t.clock().UnixNano() / t.window
+	if cur, ok := t.bucket(n); ok {
+		return cur.id
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if cur, ok := t.bucket(n); ok {
+		return cur.id
+	}
+	next := &bucket{n: n, id: t.base.TraceID()}
+	t.current.Store(next)
+	return next.id
+}
+
+// bucket returns the current bucket if it is that of window n or a newer one,
+// so the clock stepping back does not rotate.
+func (t *timeBucketed) bucket(n int64) (*bucket, bool) {
+	cur, _ := t.current.Load().(*bucket)
+	return cur, cur != nil && cur.n >= n
+}
@@ -0,0 +1,99 @@
+package idgenerator
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ximply/traceid"
+)
+
+func TestTimeBucketed(t *testing.T) {
+	now := time.Date(2024, 5, 17, 13, 4, 59, 0, time.UTC)
+	var mtx sync.Mutex
+	clock := func() time.Time {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return now
+	}
+	set := func(t time.Time) {
+		mtx.Lock()
+		now = t
+		mtx.Unlock()
+	}
+	gen := NewTimeBucketed(NewSequential(traceid.TraceID{Low: 1}), 5*time.Minute, WithClock(clock))
+
+	first := gen.TraceID()
+	if again := gen.TraceID(); again != first {
+		t.Errorf("same window: got %v, want %v", again, first)
+	}
+	set(time.Date(2024, 5, 17, 13, 5, 0, 0, time.UTC))
+	second := gen.TraceID()
+	if second == first {
+		t.Errorf("next window kept %v", first)
+	}
+	set(time.Date(2024, 5, 17, 13, 9, 59, 999999999, time.UTC))
+	if got := gen.TraceID(); got != second {
+		t.Errorf("end of window: got %v, want %v", got, second)
+	}
+	set(time.Date(2024, 5, 17, 13, 4, 0, 0, time.UTC))
+	if got := gen.TraceID(); got != second {
+		t.Errorf("clock stepped back: got %v, want %v", got, second)
+	}
+}
+
+func TestTimeBucketedConcurrent(t *testing.T) {
+	gen := NewTimeBucketed(NewRandom128(), time.Hour, WithClock(fixedClock(time.Unix(1700000000, 0))))
+	want := gen.TraceID()
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				if got := gen.TraceID(); got != want {
+					t.Errorf("got %v, want %v", got, want)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// countingGen returns ids 1, 2, ... and counts its calls.
+type countingGen struct {
+	calls uint64
+}
+
+func (g *countingGen) TraceID() traceid.TraceID {
+	return traceid.TraceID{Low: atomic.AddUint64(&g.calls, 1)}
+}
+
+func TestTimeBucketedBoundary(t *testing.T) {
+	base := &countingGen{}
+	gen := NewTimeBucketed(base, time.Hour, WithClock(fixedClock(time.Unix(1700000000, 0))))
+	start := make(chan struct{})
+	ids := make([]traceid.TraceID, 8)
+	var wg sync.WaitGroup
+	for w := range ids {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			<-start
+			ids[w] = gen.TraceID()
+		}(w)
+	}
+	close(start)
+	wg.Wait()
+	if base.calls != 1 {
+		t.Errorf("racing callers drew %d ids of base, want 1", base.calls)
+	}
+	for _, id := range ids {
+		if id != ids[0] {
+			t.Errorf("racing callers got %v, want all of them %v", ids, ids[0])
+			break
+		}
+	}
+}
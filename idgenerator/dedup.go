@@ -0,0 +1,63 @@
+package idgenerator
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/ximply/traceid"
+)
+
+// NewDeduplicating returns an ID Generator which asks keyFn for the key of
+// the current operation, such as a message id, and hands out the same id for
+// the same key as long as the key is among the capacity most recently used
+// keys. Once a key has been evicted it gets a fresh id from base. An empty
+// key is never deduplicated. It panics if capacity is not positive.
+func NewDeduplicating(base IDGenerator, capacity int, keyFn func() string) IDGenerator {
+	if capacity <= 0 {
+		panic("idgenerator: NewDeduplicating needs a positive capacity")
+	}
+	return &deduplicating{
+		base:     base,
+		keyFn:    keyFn,
+		capacity: capacity,
+		lru:      list.New(),
+		entries:  make(map[string]*list.Element, capacity),
+	}
+}
+
+// deduplicating is an LRU cache of key to traceid, most recently used first.
+type deduplicating struct {
+	base     IDGenerator
+	keyFn    func() string
+	capacity int
+
+	mtx     sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+}
+
+type dedupEntry struct {
+	key string
+	id  traceid.TraceID
+}
+
+func (d *deduplicating) TraceID() traceid.TraceID {
+	key := d.keyFn()
+	if key == "" {
+		return d.base.TraceID()
+	}
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if e, ok := d.entries[key]; ok {
+		d.lru.MoveToFront(e)
+		return e.Value.(*dedupEntry).id
+	}
+	id := d.base.TraceID()
+	d.entries[key] = d.lru.PushFront(&dedupEntry{key: key, id: id})
+	if d.lru.Len() > d.capacity {
+		oldest := d.lru.Back()
+		d.lru.Remove(oldest)
+		delete(d.entries, oldest.Value.(*dedupEntry).key)
+	}
+	return id
+}
@@ -0,0 +1,19 @@
+package idgenerator
+
+import (
+	"github.com/ximply/traceid"
+)
+
+// NewDerived returns an ID Generator deriving every id from the key returned
+// by keyFunc with traceid.FromBytes128, so the same key always yields the
+// same id.
+func NewDerived(keyFunc func() []byte) IDGenerator {
+	return derived(keyFunc)
+}
+
+// derived maps keys to traceid's deterministically.
+type derived func() []byte
+
+func (d derived) TraceID() traceid.TraceID {
+	return traceid.FromBytes128(d())
+}
@@ -0,0 +1,22 @@
+package idgenerator
+
+import (
+	"testing"
+
+	"github.com/ximply/traceid"
+)
+
+func TestDerived(t *testing.T) {
+	key := "request-42"
+	gen := NewDerived(func() []byte { return []byte(key) })
+	want := traceid.TraceID{High: 0xd4b46f2dab2d42d8, Low: 0x7163b87a55d5f434}
+	for i := 0; i < 2; i++ {
+		if got := gen.TraceID(); got != want {
+			t.Errorf("TraceID() = %v, want %v", got, want)
+		}
+	}
+	key = "request-43"
+	if got := gen.TraceID(); got == want {
+		t.Errorf("TraceID() for another key = %v too", got)
+	}
+}
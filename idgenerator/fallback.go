@@ -0,0 +1,23 @@
+package idgenerator
+
+import (
+	"github.com/ximply/traceid"
+)
+
+// NewFallback returns an ID Generator which returns the ids of primary and
+// only asks secondary when primary returns the zero TraceID, such as a
+// generator backed by a hardware module that became unavailable.
+func NewFallback(primary, secondary IDGenerator) IDGenerator {
+	return &fallback{primary: primary, secondary: secondary}
+}
+
+type fallback struct {
+	primary, secondary IDGenerator
+}
+
+func (f *fallback) TraceID() traceid.TraceID {
+	if id := f.primary.TraceID(); !id.IsZero() {
+		return id
+	}
+	return f.secondary.TraceID()
+}
@@ -0,0 +1,55 @@
+package idgenerator
+
+import (
+	"sync"
+
+	"github.com/ximply/traceid"
+)
+
+// NewFixed returns an ID Generator which always returns id.
+func NewFixed(id traceid.TraceID) IDGenerator {
+	return fixedID(id)
+}
+
+// NewFixedSequence returns an ID Generator which plays back ids in order.
+// Once all ids have been handed out it starts over if wrap is set and panics
+// otherwise, so a golden test notices when it consumes more ids than scripted.
+// It panics if ids is empty.
+func NewFixedSequence(wrap bool, ids ...traceid.TraceID) IDGenerator {
+	if len(ids) == 0 {
+		panic("idgenerator: NewFixedSequence needs at least one id")
+	}
+	return &fixedSequence{
+		ids:  append([]traceid.TraceID(nil), ids...),
+		wrap: wrap,
+	}
+}
+
+// fixedID returns the same traceid on every call.
+type fixedID traceid.TraceID
+
+func (f fixedID) TraceID() traceid.TraceID {
+	return traceid.TraceID(f)
+}
+
+// fixedSequence plays back a scripted list of traceid's.
+type fixedSequence struct {
+	mtx  sync.Mutex
+	ids  []traceid.TraceID
+	next int
+	wrap bool
+}
+
+func (f *fixedSequence) TraceID() (id traceid.TraceID) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if f.next == len(f.ids) {
+		if !f.wrap {
+			panic("idgenerator: fixed sequence exhausted")
+		}
+		f.next = 0
+	}
+	id = f.ids[f.next]
+	f.next++
+	return
+}
@@ -0,0 +1,83 @@
+package idgenerator
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ximply/traceid"
+)
+
+var script = []traceid.TraceID{{Low: 1}, {Low: 2}, {High: 3, Low: 3}}
+
+func TestFixed(t *testing.T) {
+	id := traceid.TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	gen := NewFixed(id)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				if got := gen.TraceID(); got != id {
+					t.Errorf("TraceID() = %v, want %v", got, id)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestFixedSequence(t *testing.T) {
+	ids := append([]traceid.TraceID(nil), script...)
+	gen := NewFixedSequence(false, ids...)
+	ids[0] = traceid.TraceID{Low: 99}
+	for i, want := range script {
+		if got := gen.TraceID(); got != want {
+			t.Errorf("id %d = %v, want %v", i, got, want)
+		}
+	}
+	mustPanic(t, "exhausted NewFixedSequence", func() { gen.TraceID() })
+	mustPanic(t, "NewFixedSequence without ids", func() { NewFixedSequence(true) })
+
+	gen = NewFixedSequence(true, script...)
+	for i := 0; i < 3*len(script); i++ {
+		if got, want := gen.TraceID(), script[i%len(script)]; got != want {
+			t.Errorf("id %d = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestFixedSequenceConcurrent(t *testing.T) {
+	const workers, rounds = 8, 300
+	gen := NewFixedSequence(true, script...)
+	counts := make(chan map[traceid.TraceID]int, workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			seen := make(map[traceid.TraceID]int)
+			for i := 0; i < rounds; i++ {
+				seen[gen.TraceID()]++
+			}
+			counts <- seen
+		}()
+	}
+	total := make(map[traceid.TraceID]int)
+	for w := 0; w < workers; w++ {
+		for id, n := range <-counts {
+			total[id] += n
+		}
+	}
+	for _, id := range script {
+		if got, want := total[id], workers*rounds/len(script); got != want {
+			t.Errorf("%v handed out %d times, want %d", id, got, want)
+		}
+	}
+	if len(total) != len(script) {
+		t.Errorf("handed out %v, want only %v", total, script)
+	}
+	// the playback position is shared, so the sequence continues where the
+	// workers left off
+	if got := gen.TraceID(); got != script[0] {
+		t.Errorf("next id = %v, want %v", got, script[0])
+	}
+}
@@ -2,10 +2,21 @@
 Package idgenerator contains several Trace ID generators which can be
 used by the Zipkin tracer. Additional third party generators can be plugged in
 if they adhere to the IDGenerator interface.
+
+NewSecureRandom64 and NewSecureRandom128 read from crypto/rand and are the
+recommended default. The math/rand based generators (NewRandom64,
+NewRandom128 and NewRandomTimestamped) are faster but their output can be
+predicted by anyone who can guess the process start time.
+
+The generators drawing random bits, from the random and secure ones to the
+timestamped, ULID and UUID generators, never return a zero Low and so never
+the zero TraceID: they draw again until Low is non-zero.
 */
 package idgenerator
 
 import (
+	crand "crypto/rand"
+	"encoding/binary"
 	"math/rand"
 	"sync"
 	"time"
@@ -13,69 +24,195 @@ import (
 	"github.com/ximply/traceid"
 )
 
-var (
-	seededIDGen = rand.New(rand.NewSource(time.Now().UnixNano()))
-	// NewSource returns a new pseudo-random Source seeded with the given value.
-	// Unlike the default Source used by top-level functions, this source is not
-	// safe for concurrent use by multiple goroutines. Hence the need for a mutex.
-	seededIDLock sync.Mutex
-)
-
 // IDGenerator interface can be used to provide the Zipkin Tracer with custom
 // implementations to generate Trace IDs.
 type IDGenerator interface {
-	TraceID() traceid.TraceID                // Generates a new Trace ID
+	TraceID() traceid.TraceID // Generates a new Trace ID
+}
+
+// SpanIDGenerator is implemented by generators which can also generate Span
+// IDs. traceID is the trace the new span belongs to; the random generators
+// of this package do not use it. Span IDs are never zero.
+type SpanIDGenerator interface {
+	SpanID(traceID traceid.TraceID) traceid.SpanID // Generates a new Span ID
 }
 
 // NewRandom64 returns an ID Generator which can generate 64 bit trace
-func NewRandom64() IDGenerator {
-	return &randomID64{}
+func NewRandom64(opts ...Option) IDGenerator {
+	return &randomID64{lockedRand: newLockedRand(newConfig(opts))}
 }
 
 // NewRandom128 returns an ID Generator which can generate 128 bit trace
-func NewRandom128() IDGenerator {
-	return &randomID128{}
+func NewRandom128(opts ...Option) IDGenerator {
+	return &randomID128{lockedRand: newLockedRand(newConfig(opts))}
+}
+
+// NewRandom64WithSeed returns a 64 bit ID Generator producing the same
+// sequence for the same seed, for reproducible tests.
+func NewRandom64WithSeed(seed int64) IDGenerator {
+	return NewRandom64(WithSource(rand.NewSource(seed)))
+}
+
+// NewRandom128WithSeed returns a 128 bit ID Generator producing the same
+// sequence for the same seed, for reproducible tests.
+func NewRandom128WithSeed(seed int64) IDGenerator {
+	return NewRandom128(WithSource(rand.NewSource(seed)))
 }
 
 // NewRandomTimestamped generates 128 bit time sortable traceid's
-func NewRandomTimestamped() IDGenerator {
-	return &randomTimestamped{}
+func NewRandomTimestamped(opts ...Option) IDGenerator {
+	c := newConfig(opts)
+	return &randomTimestamped{lockedRand: newLockedRand(c), clock: c.epochClock()}
+}
+
+// NewRandomTimestampedWithEpoch generates 128 bit time sortable traceid's
+// encoding the seconds since epoch, see WithEpoch.
+func NewRandomTimestampedWithEpoch(epoch time.Time) IDGenerator {
+	return NewRandomTimestamped(WithEpoch(epoch))
+}
+
+// lockedRand is a *rand.Rand, which is not safe for concurrent use, guarded by
+// a mutex unless the caller opted out with WithoutLocking.
+type lockedRand struct {
+	mtx          sync.Mutex
+	nolock       bool
+	positiveOnly bool
+	rnd          *rand.Rand
+}
+
+func newLockedRand(c *config) lockedRand {
+	src := c.src
+	if src == nil {
+		src = rand.NewSource(newSeed())
+	}
+	return lockedRand{
+		nolock:       c.nolock,
+		positiveOnly: c.positiveOnly,
+		rnd:          rand.New(src),
+	}
+}
+
+func (l *lockedRand) lock() {
+	if !l.nolock {
+		l.mtx.Lock()
+	}
+}
+
+func (l *lockedRand) unlock() {
+	if !l.nolock {
+		l.mtx.Unlock()
+	}
+}
+
+// uint64 returns 64 random bits, or 63 with WithPositiveOnly.
+func (l *lockedRand) uint64() uint64 {
+	if l.positiveOnly {
+		return uint64(l.rnd.Int63())
+	}
+	return l.rnd.Uint64()
+}
+
+// nonZeroUint64 is like uint64 but draws again rather than return zero.
+func (l *lockedRand) nonZeroUint64() (v uint64) {
+	for v == 0 {
+		v = l.uint64()
+	}
+	return
+}
+
+// uint32 returns 32 random bits, or 31 with WithPositiveOnly.
+func (l *lockedRand) uint32() uint32 {
+	if l.positiveOnly {
+		return uint32(l.rnd.Int31())
+	}
+	return l.rnd.Uint32()
+}
+
+// newSeed returns a seed of its own for every generator, so that generators
+// created at the same instant still produce different streams.
+func newSeed() int64 {
+	var b [8]byte
+	seed := time.Now().UnixNano()
+	if _, err := crand.Read(b[:]); err == nil {
+		seed ^= int64(binary.BigEndian.Uint64(b[:]))
+	}
+	return seed
 }
 
 // randomID64 can generate 64 bit traceid's and 64 bit spanid's.
-type randomID64 struct{}
+type randomID64 struct {
+	lockedRand
+}
 
 func (r *randomID64) TraceID() (id traceid.TraceID) {
-	seededIDLock.Lock()
-	id = traceid.TraceID{
-		Low: uint64(seededIDGen.Int63()),
+	r.lock()
+	id = r.traceID()
+	r.unlock()
+	return
+}
+
+func (r *randomID64) traceID() traceid.TraceID {
+	return traceid.TraceID{
+		Low: r.nonZeroUint64(),
 	}
-	seededIDLock.Unlock()
+}
+
+func (r *randomID64) SpanID(traceID traceid.TraceID) (id traceid.SpanID) {
+	r.lock()
+	id = traceid.SpanID(r.nonZeroUint64())
+	r.unlock()
 	return
 }
 
 // randomID128 can generate 128 bit traceid's
-type randomID128 struct{}
+type randomID128 struct {
+	lockedRand
+}
 
 func (r *randomID128) TraceID() (id traceid.TraceID) {
-	seededIDLock.Lock()
-	id = traceid.TraceID{
-		High: uint64(seededIDGen.Int63()),
-		Low:  uint64(seededIDGen.Int63()),
+	r.lock()
+	id = r.traceID()
+	r.unlock()
+	return
+}
+
+func (r *randomID128) traceID() traceid.TraceID {
+	return traceid.TraceID{
+		High: r.uint64(),
+		Low:  r.nonZeroUint64(),
 	}
-	seededIDLock.Unlock()
+}
+
+func (r *randomID128) SpanID(traceID traceid.TraceID) (id traceid.SpanID) {
+	r.lock()
+	id = traceid.SpanID(r.nonZeroUint64())
+	r.unlock()
 	return
 }
 
 // randomTimestamped can generate 128 bit time sortable traceid's compatible
-type randomTimestamped struct{}
+type randomTimestamped struct {
+	lockedRand
+	clock epochClock
+}
 
 func (t *randomTimestamped) TraceID() (id traceid.TraceID) {
-	seededIDLock.Lock()
-	id = traceid.TraceID{
-		High: uint64(time.Now().Unix()<<32) + uint64(seededIDGen.Int31()),
-		Low:  uint64(seededIDGen.Int63()),
+	t.lock()
+	id = t.traceID()
+	t.unlock()
+	return
+}
+
+func (t *randomTimestamped) traceID() traceid.TraceID {
+	return traceid.TraceID{
+		High: uint64(t.clock.seconds()<<32) + uint64(t.uint32()),
+		Low:  t.nonZeroUint64(),
 	}
-	seededIDLock.Unlock()
+}
+
+func (t *randomTimestamped) SpanID(traceID traceid.TraceID) (id traceid.SpanID) {
+	t.lock()
+	id = traceid.SpanID(t.nonZeroUint64())
+	t.unlock()
 	return
-}
\ No newline at end of file
+}
@@ -0,0 +1,194 @@
+package idgenerator
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ximply/traceid"
+)
+
+// scriptedSource is a rand.Source64 playing back vals, for tests which need
+// exact random bits. rand.Rand takes Uint32 from the upper 32 bits of a value.
+type scriptedSource struct {
+	vals []uint64
+	next int
+}
+
+func (s *scriptedSource) Uint64() uint64 {
+	v := s.vals[s.next%len(s.vals)]
+	s.next++
+	return v
+}
+
+func (s *scriptedSource) Int63() int64 { return int64(s.Uint64() >> 1) }
+
+func (s *scriptedSource) Seed(int64) {}
+
+func BenchmarkSecureRandom128(b *testing.B) {
+	gen := NewSecureRandom128()
+	for i := 0; i < b.N; i++ {
+		gen.TraceID()
+	}
+}
+
+func BenchmarkRandom128(b *testing.B) {
+	gen := NewRandom128WithSeed(1)
+	for i := 0; i < b.N; i++ {
+		gen.TraceID()
+	}
+}
+
+func BenchmarkRandom128Parallel(b *testing.B) {
+	gen := NewRandom128()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			gen.TraceID()
+		}
+	})
+}
+
+func BenchmarkRandom128PerGoroutine(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		gen := NewRandom128()
+		for pb.Next() {
+			gen.TraceID()
+		}
+	})
+}
+
+// TestIndependentGenerators checks that generators share no state: seeded
+// ones produce the same ids whatever other generators do concurrently.
+func TestIndependentGenerators(t *testing.T) {
+	const n = 10000
+	want := make([]traceid.TraceID, n)
+	gen := NewRandom128WithSeed(1)
+	for i := range want {
+		want[i] = gen.TraceID()
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			gen := NewRandom128WithSeed(1)
+			for i := range want {
+				if got := gen.TraceID(); got != want[i] {
+					t.Errorf("id %d = %v, want %v", i, got, want[i])
+					return
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			gens := []IDGenerator{NewRandom64(), NewRandom128(), NewRandomTimestamped()}
+			for i := 0; i < n; i++ {
+				gens[i%len(gens)].TraceID()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSharedGenerator(t *testing.T) {
+	const workers, n = 8, 10000
+	gen := NewRandom128()
+	ids := make(chan traceid.TraceID, workers*n)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				ids <- gen.TraceID()
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+	seen := make(map[traceid.TraceID]bool, workers*n)
+	for id := range ids {
+		if seen[id] {
+			t.Fatalf("duplicate id %v", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestTopBit(t *testing.T) {
+	const top = 1 << 63
+	var high, low bool
+	gen := NewRandom128()
+	for i := 0; i < 1000; i++ {
+		id := gen.TraceID()
+		high = high || id.High&top != 0
+		low = low || id.Low&top != 0
+	}
+	if !high || !low {
+		t.Errorf("Random128: top bit never set in 1000 ids, High %v, Low %v", high, low)
+	}
+	low = false
+	gen = NewRandom64()
+	for i := 0; i < 1000; i++ {
+		low = low || gen.TraceID().Low&top != 0
+	}
+	if !low {
+		t.Error("Random64: top bit never set in 1000 ids")
+	}
+}
+
+func TestWithPositiveOnly(t *testing.T) {
+	const top = 1 << 63
+	for name, gen := range map[string]IDGenerator{
+		"Random64":  NewRandom64(WithPositiveOnly()),
+		"Random128": NewRandom128(WithPositiveOnly()),
+	} {
+		for i := 0; i < 10000; i++ {
+			if id := gen.TraceID(); id.High&top != 0 || id.Low&top != 0 {
+				t.Fatalf("%s: TraceID() = %v has the top bit set", name, id)
+			}
+		}
+	}
+}
+
+func TestZeroSourceRetries(t *testing.T) {
+	clock := WithClock(fixedClock(time.Date(2024, 5, 17, 13, 37, 42, 0, time.UTC)))
+	for name, newGen := range map[string]func(...Option) IDGenerator{
+		"Random64":                NewRandom64,
+		"Random128":               NewRandom128,
+		"RandomTimestamped":       NewRandomTimestamped,
+		"RandomTimestampedMillis": NewRandomTimestampedMillis,
+		"MonotonicTimestamped":    NewMonotonicTimestamped,
+		"ULID":                    NewULID,
+		"XRayCompatible":          NewXRayCompatible,
+	} {
+		// zeros for every draw of the first id, then a single set bit
+		src := &scriptedSource{vals: []uint64{0, 0, 0, 0, 1 << 40}}
+		id := newGen(WithSource(src), clock).TraceID()
+		if id.Low == 0 {
+			t.Errorf("%s: TraceID() = %#v has a zero Low", name, id)
+		}
+		if src.next <= 2 {
+			t.Errorf("%s: drew %d values, want it to retry", name, src.next)
+		}
+		gen := newGen(WithSource(&scriptedSource{vals: []uint64{0, 0, 0, 1 << 40}}), clock)
+		if sg, ok := gen.(SpanIDGenerator); ok {
+			if span := sg.SpanID(id); span.IsZero() {
+				t.Errorf("%s: SpanID() is zero", name)
+			}
+		}
+	}
+}
+
+func TestSecureNonZero(t *testing.T) {
+	for name, gen := range map[string]IDGenerator{
+		"SecureRandom64":  NewSecureRandom64(),
+		"SecureRandom128": NewSecureRandom128(),
+	} {
+		for i := 0; i < 1000; i++ {
+			if id := gen.TraceID(); id.Low == 0 {
+				t.Fatalf("%s: TraceID() = %#v has a zero Low", name, id)
+			}
+		}
+	}
+}
@@ -0,0 +1,9 @@
+package idgenerator
+
+// NewKernelUUID returns an ID Generator producing version 4 UUIDs read from
+// /proc/sys/kernel/random/uuid on Linux, which is opened once here and read
+// again for every id. Elsewhere the UUIDs are drawn from crypto/rand. On
+// Linux the returned generator implements io.Closer to release the file.
+func NewKernelUUID() (IDGenerator, error) {
+	return newKernelUUID()
+}
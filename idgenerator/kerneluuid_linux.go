@@ -0,0 +1,39 @@
+package idgenerator
+
+import (
+	"os"
+
+	"github.com/ximply/traceid"
+)
+
+const kernelUUIDPath = "/proc/sys/kernel/random/uuid"
+
+func newKernelUUID() (IDGenerator, error) {
+	f, err := os.Open(kernelUUIDPath)
+	if err != nil {
+		return nil, err
+	}
+	return &kernelUUID{f: f}, nil
+}
+
+// kernelUUID reads traceid's from the kernel's UUID file. Every read at
+// offset 0 returns a new UUID, so concurrent callers need no lock.
+type kernelUUID struct {
+	f *os.File
+}
+
+func (k *kernelUUID) TraceID() traceid.TraceID {
+	var b [36]byte
+	if n, err := k.f.ReadAt(b[:], 0); n < len(b) {
+		panic("idgenerator: reading " + kernelUUIDPath + " failed: " + err.Error())
+	}
+	id, err := traceid.ParseUUID(string(b[:]))
+	if err != nil {
+		panic("idgenerator: reading " + kernelUUIDPath + " failed: " + err.Error())
+	}
+	return id
+}
+
+func (k *kernelUUID) Close() error {
+	return k.f.Close()
+}
@@ -0,0 +1,21 @@
+//go:build !linux
+// +build !linux
+
+package idgenerator
+
+import (
+	"github.com/ximply/traceid"
+)
+
+func newKernelUUID() (IDGenerator, error) {
+	return secureUUIDv4{}, nil
+}
+
+// secureUUIDv4 can generate version 4 UUID traceid's from crypto/rand.
+type secureUUIDv4 struct{}
+
+func (secureUUIDv4) TraceID() traceid.TraceID {
+	id := (&secureRandom128{}).TraceID()
+	setUUIDVersion(&id, 4)
+	return id
+}
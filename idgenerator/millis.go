@@ -0,0 +1,44 @@
+package idgenerator
+
+import (
+	"time"
+
+	"github.com/ximply/traceid"
+)
+
+// NewRandomTimestampedMillis generates 128 bit time sortable traceid's with
+// millisecond resolution. The epoch defaults to the Unix epoch, see
+// WithEpoch. The bit layout is:
+//
+//	High bits 63-16: milliseconds since the epoch (48 bits, about 8900 years)
+//	High bits 15-0:  random
+//	Low  bits 63-0:  random, never zero
+func NewRandomTimestampedMillis(opts ...Option) IDGenerator {
+	c := newConfig(opts)
+	return &randomTimestampedMillis{lockedRand: newLockedRand(c), clock: c.epochClock()}
+}
+
+// MillisOf returns the time encoded in a traceid generated by
+// NewRandomTimestampedMillis. Only the WithEpoch option is used.
+func MillisOf(id traceid.TraceID, opts ...Option) time.Time {
+	c := newConfig(opts)
+	return epochClock{epoch: c.epoch}.at(int64(id.High >> 16))
+}
+
+// randomTimestampedMillis can generate 128 bit traceid's sortable by
+// millisecond
+type randomTimestampedMillis struct {
+	lockedRand
+	clock epochClock
+}
+
+func (t *randomTimestampedMillis) TraceID() (id traceid.TraceID) {
+	ms := uint64(t.clock.millis())
+	t.lock()
+	id = traceid.TraceID{
+		High: ms<<16 | uint64(t.uint32()&0xffff),
+		Low:  t.nonZeroUint64(),
+	}
+	t.unlock()
+	return
+}
@@ -0,0 +1,51 @@
+package idgenerator
+
+import (
+	"math"
+	"runtime"
+
+	"github.com/ximply/traceid"
+)
+
+// NewMonotonicTimestamped generates 128 bit time sortable traceid's which
+// strictly increase from one call to the next. High holds the seconds since
+// the epoch (see WithEpoch) in its upper 32 bits and a per second sequence
+// number in its lower 32 bits, Low is random and never zero. When the
+// sequence of a second is exhausted the generator spins until the clock
+// reaches the next second. If the clock steps back the generator keeps
+// counting in the last second it saw.
+func NewMonotonicTimestamped(opts ...Option) IDGenerator {
+	c := newConfig(opts)
+	return &monotonicTimestamped{lockedRand: newLockedRand(c), clock: c.epochClock()}
+}
+
+// monotonicTimestamped can generate strictly increasing 128 bit traceid's
+type monotonicTimestamped struct {
+	lockedRand
+	clock epochClock
+	sec   uint64
+	seq   uint64
+}
+
+func (m *monotonicTimestamped) TraceID() (id traceid.TraceID) {
+	m.lock()
+	sec := uint64(m.clock.seconds())
+	switch {
+	case sec > m.sec:
+		m.sec, m.seq = sec, 0
+	case m.seq == math.MaxUint32:
+		for sec <= m.sec {
+			runtime.Gosched()
+			sec = uint64(m.clock.seconds())
+		}
+		m.sec, m.seq = sec, 0
+	default:
+		m.seq++
+	}
+	id = traceid.TraceID{
+		High: m.sec<<32 | m.seq,
+		Low:  m.nonZeroUint64(),
+	}
+	m.unlock()
+	return
+}
@@ -0,0 +1,62 @@
+package idgenerator
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ximply/traceid"
+)
+
+func TestMonotonicTimestampedConcurrent(t *testing.T) {
+	const workers, perWorker = 8, 50000
+	gen := NewMonotonicTimestamped()
+	results := make([][]traceid.TraceID, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			ids := make([]traceid.TraceID, perWorker)
+			for i := range ids {
+				ids[i] = gen.TraceID()
+			}
+			results[w] = ids
+		}(w)
+	}
+	wg.Wait()
+
+	var all []traceid.TraceID
+	for w, ids := range results {
+		for i := 1; i < len(ids); i++ {
+			if !ids[i-1].Less(ids[i]) {
+				t.Fatalf("worker %d: id %d %v does not sort after %v", w, i, ids[i], ids[i-1])
+			}
+		}
+		all = append(all, ids...)
+	}
+	traceid.SortSlice(all)
+	for i := 1; i < len(all); i++ {
+		if all[i] == all[i-1] {
+			t.Fatalf("duplicate id %v", all[i])
+		}
+	}
+}
+
+func TestMonotonicTimestampedClockStepsBack(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	var mtx sync.Mutex
+	clock := func() time.Time {
+		mtx.Lock()
+		defer mtx.Unlock()
+		return now
+	}
+	gen := NewMonotonicTimestamped(WithClock(clock))
+	first := gen.TraceID()
+	mtx.Lock()
+	now = now.Add(-time.Minute)
+	mtx.Unlock()
+	if second := gen.TraceID(); !first.Less(second) {
+		t.Errorf("after the clock stepped back %v does not sort after %v", second, first)
+	}
+}
@@ -0,0 +1,129 @@
+package idgenerator
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Option configures the math/rand based generators.
+type Option func(*config)
+
+type config struct {
+	src          rand.Source
+	nolock       bool
+	positiveOnly bool
+	clock        func() time.Time
+	epoch        time.Time
+	epochSet     bool
+	monotonic    bool
+}
+
+// unixEpoch is the default epoch of the timestamped generators.
+var unixEpoch = time.Unix(0, 0)
+
+func newConfig(opts []Option) *config {
+	c := &config{clock: time.Now, epoch: unixEpoch}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithSource makes the generator draw from src instead of a privately seeded
+// source, e.g. to reproduce the IDs of a test run. Calls into src are
+// serialized by the generator unless WithoutLocking is given as well.
+func WithSource(src rand.Source) Option {
+	return func(c *config) {
+		c.src = src
+	}
+}
+
+// WithoutLocking drops the generator's mutex. Only use it when the generator
+// is never called from more than one goroutine at a time.
+func WithoutLocking() Option {
+	return func(c *config) {
+		c.nolock = true
+	}
+}
+
+// WithPositiveOnly keeps the top bit of every generated word clear, as older
+// versions of this package did, for downstream systems that store trace ids
+// as signed 64 bit integers.
+func WithPositiveOnly() Option {
+	return func(c *config) {
+		c.positiveOnly = true
+	}
+}
+
+// WithClock makes the timestamped generators read the time from clock
+// instead of time.Now.
+func WithClock(clock func() time.Time) Option {
+	return func(c *config) {
+		c.clock = clock
+	}
+}
+
+// WithEpoch makes the timestamped generators encode the time elapsed since
+// epoch instead of since the Unix epoch. Pass the same option to the helpers
+// decoding the time, such as MillisOf, to get it back. The generator
+// constructors panic if epoch lies in the future, and NewULID, NewUUIDv7 and
+// NewXRayCompatible, whose formats fix the Unix epoch, panic if it is given
+// at all.
+func WithEpoch(epoch time.Time) Option {
+	return func(c *config) {
+		c.epoch = epoch
+		c.epochSet = true
+	}
+}
+
+// unixEpochOnly rejects WithEpoch for the generators of formats defined on
+// the Unix epoch; constructor names the generator in the panic.
+func (c *config) unixEpochOnly(constructor string) {
+	if c.epochSet {
+		panic("idgenerator: " + constructor + " encodes Unix time and does not take WithEpoch")
+	}
+}
+
+// epochClock returns the clock for a timestamped generator, rejecting epochs
+// which lie in the future.
+func (c *config) epochClock() epochClock {
+	if c.epoch.After(c.clock()) {
+		panic("idgenerator: epoch " + c.epoch.String() + " lies in the future")
+	}
+	return epochClock{now: c.clock, epoch: c.epoch}
+}
+
+// epochClock reads the time relative to an epoch.
+type epochClock struct {
+	now   func() time.Time
+	epoch time.Time
+}
+
+// seconds returns the whole seconds elapsed since the epoch.
+func (c epochClock) seconds() int64 {
+	return c.now().Unix() - c.epoch.Unix()
+}
+
+// millis returns the whole milliseconds elapsed since the epoch.
+func (c epochClock) millis() int64 {
+	now := c.now()
+	ns := int64(now.Nanosecond() - c.epoch.Nanosecond())
+	if ns < 0 {
+		ns -= 1e6 - 1
+	}
+	return (now.Unix()-c.epoch.Unix())*1e3 + ns/1e6
+}
+
+// at returns the time lying ms milliseconds after the epoch.
+func (c epochClock) at(ms int64) time.Time {
+	return time.Unix(c.epoch.Unix()+ms/1e3, int64(c.epoch.Nanosecond())+ms%1e3*1e6)
+}
+
+// WithMonotonic makes the ULID generator increment the random part of the
+// previous id instead of drawing a new one when called again within the same
+// millisecond, as described by the ULID specification.
+func WithMonotonic() Option {
+	return func(c *config) {
+		c.monotonic = true
+	}
+}
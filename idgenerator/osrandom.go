@@ -0,0 +1,45 @@
+package idgenerator
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/ximply/traceid"
+)
+
+// OSRandom is an ID Generator reading 128 bit trace ids straight from the
+// operating system's random device, skipping the layers of crypto/rand. It
+// is safe for concurrent use.
+type OSRandom struct {
+	src io.ReadCloser
+}
+
+// NewOSRandom128 returns an OSRandom reading from /dev/urandom, opened once
+// here, on Linux and from crypto/rand elsewhere. Release the file with Close.
+func NewOSRandom128() (*OSRandom, error) {
+	src, err := openOSRandom()
+	if err != nil {
+		return nil, err
+	}
+	return &OSRandom{src: src}, nil
+}
+
+// TraceID returns the next id. Like the crypto/rand generators it panics if
+// the random device fails.
+func (o *OSRandom) TraceID() (id traceid.TraceID) {
+	var b [16]byte
+	for id.Low == 0 {
+		if _, err := io.ReadFull(o.src, b[:]); err != nil {
+			panic("idgenerator: reading from the OS random device failed: " + err.Error())
+		}
+		id.High = binary.BigEndian.Uint64(b[:8])
+		id.Low = binary.BigEndian.Uint64(b[8:])
+	}
+	return
+}
+
+// Close releases the random device. The generator must not be used
+// afterwards.
+func (o *OSRandom) Close() error {
+	return o.src.Close()
+}
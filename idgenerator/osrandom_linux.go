@@ -0,0 +1,10 @@
+package idgenerator
+
+import (
+	"io"
+	"os"
+)
+
+func openOSRandom() (io.ReadCloser, error) {
+	return os.Open("/dev/urandom")
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+// +build !linux
+
+package idgenerator
+
+import (
+	"crypto/rand"
+	"io"
+	"io/ioutil"
+)
+
+func openOSRandom() (io.ReadCloser, error) {
+	return ioutil.NopCloser(rand.Reader), nil
+}
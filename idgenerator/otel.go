@@ -0,0 +1,41 @@
+package idgenerator
+
+import (
+	"github.com/ximply/traceid"
+)
+
+// OTelAdapter hands out the ids of an ID Generator in the [16]byte and [8]byte
+// forms of OpenTelemetry's trace.TraceID and trace.SpanID, so it can back an
+// sdktrace.IDGenerator without this module importing the OpenTelemetry SDK:
+//
+//	func (g otelGen) NewIDs(ctx context.Context) (trace.TraceID, trace.SpanID) {
+//		tid := g.TraceIDBytes()
+//		return tid, g.SpanIDBytes(tid)
+//	}
+type OTelAdapter struct {
+	inner IDGenerator
+}
+
+// NewOTelAdapter returns an OTelAdapter for inner.
+func NewOTelAdapter(inner IDGenerator) *OTelAdapter {
+	return &OTelAdapter{inner: inner}
+}
+
+// TraceIDBytes returns the next id of the wrapped generator.
+func (o *OTelAdapter) TraceIDBytes() [16]byte {
+	return o.inner.TraceID().OTelBytes()
+}
+
+// SpanIDBytes returns a non-zero span id for the given trace id, from the
+// wrapped generator if it is a SpanIDGenerator and from crypto/rand
+// otherwise.
+func (o *OTelAdapter) SpanIDBytes(traceID [16]byte) [8]byte {
+	var id traceid.SpanID
+	if g, ok := o.inner.(SpanIDGenerator); ok {
+		id = g.SpanID(traceid.FromOTel(traceID))
+	}
+	if id.IsZero() {
+		id = secureSpanID()
+	}
+	return id.Bytes()
+}
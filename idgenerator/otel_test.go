@@ -0,0 +1,38 @@
+package idgenerator
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ximply/traceid"
+)
+
+// noSpanIDs hides the SpanIDGenerator of a generator.
+type noSpanIDs struct {
+	IDGenerator
+}
+
+func TestOTelAdapter(t *testing.T) {
+	id := traceid.TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	o := NewOTelAdapter(NewFixed(id))
+	tid := o.TraceIDBytes()
+	if got, want := hex.EncodeToString(tid[:]), "4bf92f3577b34da6a3ce929d0e0e4736"; got != want {
+		t.Errorf("TraceIDBytes() = %s, want %s", got, want)
+	}
+	for i := 0; i < 100; i++ {
+		if sid := o.SpanIDBytes(tid); sid == ([8]byte{}) {
+			t.Fatal("SpanIDBytes() is zero")
+		}
+	}
+
+	// a SpanIDGenerator is asked for the span id
+	src := &scriptedSource{vals: []uint64{0x00f067aa0ba902b7}}
+	o = NewOTelAdapter(NewRandom128(WithSource(src)))
+	if got, want := o.SpanIDBytes(tid), traceid.SpanID(0x00f067aa0ba902b7).Bytes(); got != want {
+		t.Errorf("SpanIDBytes() = %x, want %x", got, want)
+	}
+	o = NewOTelAdapter(noSpanIDs{NewFixed(id)})
+	if sid := o.SpanIDBytes(tid); sid == ([8]byte{}) {
+		t.Error("SpanIDBytes() without a SpanIDGenerator is zero")
+	}
+}
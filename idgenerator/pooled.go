@@ -0,0 +1,105 @@
+package idgenerator
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/ximply/traceid"
+)
+
+// Pooled is an ID Generator which hands out ids pre-generated by a background
+// goroutine, so that TraceID is usually just a channel receive.
+type Pooled struct {
+	inner IDGenerator
+	ids   chan traceid.TraceID
+	done  chan struct{}
+	once  sync.Once
+}
+
+// NewPooled returns a Pooled generator keeping up to size ids of inner ready.
+// inner is called from the refill goroutine and from TraceID when the pool
+// runs dry, so it must be safe for concurrent use. Call Close to stop the
+// refill goroutine.
+func NewPooled(inner IDGenerator, size int) *Pooled {
+	if size < 1 {
+		size = 1
+	}
+	p := &Pooled{
+		inner: inner,
+		ids:   make(chan traceid.TraceID, size),
+		done:  make(chan struct{}),
+	}
+	go p.refill()
+	return p
+}
+
+func (p *Pooled) refill() {
+	for {
+		id := p.inner.TraceID()
+		select {
+		case p.ids <- id:
+		case <-p.done:
+			return
+		}
+	}
+}
+
+// TraceID returns a pooled id, or generates one inline if the pool is empty.
+// It never blocks on the refill goroutine.
+func (p *Pooled) TraceID() traceid.TraceID {
+	select {
+	case id := <-p.ids:
+		return id
+	default:
+		return p.inner.TraceID()
+	}
+}
+
+// Close stops the refill goroutine. Ids still in the pool are handed out
+// before TraceID falls back to generating inline. Close may be called more
+// than once.
+func (p *Pooled) Close() error {
+	p.once.Do(func() {
+		close(p.done)
+	})
+	return nil
+}
+
+// poolShardSize is the number of ids each shard of NewPooledRandom128 keeps
+// ready.
+const poolShardSize = 256
+
+// ShardedPool spreads TraceID calls round robin over several Pooled
+// generators, each backed by its own RNG, so callers share no lock.
+type ShardedPool struct {
+	next   uint32 // accessed atomically
+	shards []*Pooled
+}
+
+// NewPooledRandom128 returns a ShardedPool of 128 bit random generators with
+// workers shards, each keeping up to 256 ids ready. Call Close to stop the
+// refill goroutines.
+func NewPooledRandom128(workers int) *ShardedPool {
+	if workers < 1 {
+		workers = 1
+	}
+	p := &ShardedPool{shards: make([]*Pooled, workers)}
+	for i := range p.shards {
+		p.shards[i] = NewPooled(NewRandom128(), poolShardSize)
+	}
+	return p
+}
+
+// TraceID returns an id from the next shard.
+func (p *ShardedPool) TraceID() traceid.TraceID {
+	n := atomic.AddUint32(&p.next, 1)
+	return p.shards[n%uint32(len(p.shards))].TraceID()
+}
+
+// Close stops the refill goroutines of all shards.
+func (p *ShardedPool) Close() error {
+	for _, s := range p.shards {
+		s.Close()
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package idgenerator
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/ximply/traceid"
+)
+
+// gatedSequential is a Sequential whose call number block blocks until
+// release is closed.
+type gatedSequential struct {
+	*Sequential
+	block   uint64
+	release chan struct{}
+}
+
+func (g *gatedSequential) TraceID() traceid.TraceID {
+	id := g.Sequential.TraceID()
+	if id.Low == g.block {
+		<-g.release
+	}
+	return id
+}
+
+// waitIssued spins until seq handed out n ids.
+func waitIssued(seq *Sequential, n uint64) {
+	for seq.Issued() < n {
+		runtime.Gosched()
+	}
+}
+
+func TestPooledExhausted(t *testing.T) {
+	const size = 4
+	// ids 1 to size fill the pool, the refill goroutine then blocks in the
+	// generation of id size+1
+	inner := &gatedSequential{
+		Sequential: NewSequential(traceid.TraceID{Low: 1}),
+		block:      size + 1,
+		release:    make(chan struct{}),
+	}
+	p := NewPooled(inner, size)
+	defer p.Close()
+	waitIssued(inner.Sequential, size+1)
+	for i := uint64(1); i <= size; i++ {
+		if got := p.TraceID(); got.Low != i {
+			t.Errorf("pooled id = %v, want %d", got, i)
+		}
+	}
+	if got := p.TraceID(); got.Low != size+2 {
+		t.Errorf("id of the empty pool = %v, want %d generated inline", got, size+2)
+	}
+	close(inner.release)
+	waitIssued(inner.Sequential, size+3)
+	if got := p.TraceID(); got.Low != size+1 {
+		t.Errorf("id after the refill resumed = %v, want %d", got, size+1)
+	}
+}
+
+func TestPooledUnique(t *testing.T) {
+	const workers, n = 8, 10000
+	p := NewPooled(NewSequential(traceid.TraceID{Low: 1}), 64)
+	defer p.Close()
+	ids := make(chan traceid.TraceID, workers*n)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				ids <- p.TraceID()
+				if w == 0 && i == n/2 {
+					p.Close()
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(ids)
+	seen := make(map[traceid.TraceID]bool, workers*n)
+	for id := range ids {
+		if seen[id] {
+			t.Fatalf("id %v handed out twice", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestPooledClose(t *testing.T) {
+	inner := NewSequential(traceid.TraceID{Low: 1})
+	p := NewPooled(inner, 8)
+	waitIssued(inner, 9)
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+	seen := make(map[traceid.TraceID]bool)
+	for i := 0; i < 100; i++ {
+		id := p.TraceID()
+		if seen[id] {
+			t.Fatalf("id %v handed out twice", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestShardedPoolUnique(t *testing.T) {
+	p := NewPooledRandom128(4)
+	defer p.Close()
+	var mtx sync.Mutex
+	seen := make(map[traceid.TraceID]bool)
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 5000; i++ {
+				id := p.TraceID()
+				mtx.Lock()
+				if seen[id] {
+					t.Errorf("id %v handed out twice", id)
+				}
+				seen[id] = true
+				mtx.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// BenchmarkPooledRandom128 compares with BenchmarkRandom128 and
+// BenchmarkRandom128Parallel.
+func BenchmarkPooledRandom128(b *testing.B) {
+	p := NewPooledRandom128(runtime.GOMAXPROCS(0))
+	defer p.Close()
+	for i := 0; i < b.N; i++ {
+		p.TraceID()
+	}
+}
+
+func BenchmarkPooledRandom128Parallel(b *testing.B) {
+	p := NewPooledRandom128(runtime.GOMAXPROCS(0))
+	defer p.Close()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			p.TraceID()
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package idgenerator
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/ximply/traceid"
+)
+
+// NewRateLimited returns an ID Generator which passes on the ids of base at
+// up to ratePerSecond per second and returns the zero TraceID, meaning "do not
+// trace this request", once the rate is exceeded. Bursts of up to one second
+// worth of ids are admitted. It never blocks. A non-positive rate admits
+// nothing.
+func NewRateLimited(base IDGenerator, ratePerSecond float64) IDGenerator {
+	r := &rateLimited{base: base, start: time.Now()}
+	if ratePerSecond > 0 {
+		r.interval = int64(math.Max(1, float64(time.Second)/ratePerSecond))
+		burst := math.Max(1, math.Ceil(ratePerSecond))
+		r.tolerance = int64(burst-1) * r.interval
+	}
+	return r
+}
+
+// rateLimited is a token bucket implemented as a generic cell rate
+// algorithm: tat is the theoretical arrival time of the next id, it moves
+// interval ahead per admitted id and may run at most tolerance ahead of now.
+type rateLimited struct {
+	tat       int64 // nanoseconds since start, accessed atomically
+	interval  int64
+	tolerance int64
+	start     time.Time
+	base      IDGenerator
+}
+
+func (r *rateLimited) TraceID() traceid.TraceID {
+	if r.interval <= 0 {
+		return traceid.TraceID{}
+	}
+	now := int64(time.Since(r.start))
+	for {
+		old := atomic.LoadInt64(&r.tat)
+		tat := old
+		if tat < now {
+			tat = now
+		}
+		if tat-now > r.tolerance {
+			return traceid.TraceID{}
+		}
+		if atomic.CompareAndSwapInt64(&r.tat, old, tat+r.interval) {
+			return r.base.TraceID()
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package idgenerator
+
+import (
+	"sync"
+
+	"github.com/ximply/traceid"
+)
+
+// Recorder is an ID Generator which delegates to another generator and keeps
+// every id it hands out, so tests can assert on them afterwards.
+type Recorder struct {
+	inner IDGenerator
+	limit int
+
+	mtx   sync.Mutex
+	ids   []traceid.TraceID
+	start int // index of the oldest id once a bounded recorder wrapped
+}
+
+// NewRecorder returns a Recorder wrapping inner which keeps all ids.
+func NewRecorder(inner IDGenerator) *Recorder {
+	return &Recorder{inner: inner}
+}
+
+// NewBoundedRecorder returns a Recorder wrapping inner which keeps only the
+// most recent capacity ids. It panics if capacity is not positive.
+func NewBoundedRecorder(inner IDGenerator, capacity int) *Recorder {
+	if capacity <= 0 {
+		panic("idgenerator: NewBoundedRecorder needs a positive capacity")
+	}
+	return &Recorder{
+		inner: inner,
+		limit: capacity,
+		ids:   make([]traceid.TraceID, 0, capacity),
+	}
+}
+
+// TraceID returns the next id of the wrapped generator and records it. The
+// lock is held across the call to the wrapped generator, so the recorded
+// order is the order in which it produced the ids.
+func (r *Recorder) TraceID() traceid.TraceID {
+	r.mtx.Lock()
+	id := r.inner.TraceID()
+	if r.limit > 0 && len(r.ids) == r.limit {
+		r.ids[r.start] = id
+		r.start = (r.start + 1) % r.limit
+	} else {
+		r.ids = append(r.ids, id)
+	}
+	r.mtx.Unlock()
+	return id
+}
+
+// Generated returns a copy of the recorded ids, oldest first.
+func (r *Recorder) Generated() []traceid.TraceID {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	ids := make([]traceid.TraceID, 0, len(r.ids))
+	ids = append(ids, r.ids[r.start:]...)
+	return append(ids, r.ids[:r.start]...)
+}
+
+// Last returns the most recently recorded id, and false if there is none.
+func (r *Recorder) Last() (traceid.TraceID, bool) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if len(r.ids) == 0 {
+		return traceid.TraceID{}, false
+	}
+	if r.start == 0 {
+		return r.ids[len(r.ids)-1], true
+	}
+	return r.ids[r.start-1], true
+}
+
+// Reset forgets all recorded ids.
+func (r *Recorder) Reset() {
+	r.mtx.Lock()
+	r.ids = r.ids[:0]
+	r.start = 0
+	r.mtx.Unlock()
+}
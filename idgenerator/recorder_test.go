@@ -0,0 +1,65 @@
+package idgenerator
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ximply/traceid"
+)
+
+func TestRecorderConcurrent(t *testing.T) {
+	const workers, perWorker = 8, 1000
+	r := NewRecorder(NewSequential(traceid.TraceID{Low: 1}))
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				r.TraceID()
+				r.Last()
+			}
+		}()
+	}
+	wg.Wait()
+
+	ids := r.Generated()
+	if len(ids) != workers*perWorker {
+		t.Fatalf("recorded %d ids, want %d", len(ids), workers*perWorker)
+	}
+	for i, id := range ids {
+		if want := (traceid.TraceID{Low: uint64(i + 1)}); id != want {
+			t.Fatalf("id %d = %v, want %v in generation order", i, id, want)
+		}
+	}
+	if last, ok := r.Last(); !ok || last != ids[len(ids)-1] {
+		t.Errorf("Last() = %v, %v, want %v", last, ok, ids[len(ids)-1])
+	}
+	r.Reset()
+	if ids := r.Generated(); len(ids) != 0 {
+		t.Errorf("Generated() after Reset = %v", ids)
+	}
+	if _, ok := r.Last(); ok {
+		t.Error("Last() after Reset reported an id")
+	}
+}
+
+func TestBoundedRecorder(t *testing.T) {
+	r := NewBoundedRecorder(NewSequential(traceid.TraceID{Low: 1}), 3)
+	for i := 0; i < 5; i++ {
+		r.TraceID()
+	}
+	want := []traceid.TraceID{{Low: 3}, {Low: 4}, {Low: 5}}
+	got := r.Generated()
+	if len(got) != len(want) {
+		t.Fatalf("Generated() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Generated() = %v, want %v", got, want)
+		}
+	}
+	if last, _ := r.Last(); last != want[2] {
+		t.Errorf("Last() = %v, want %v", last, want[2])
+	}
+}
@@ -0,0 +1,50 @@
+package idgenerator
+
+import (
+	"github.com/ximply/traceid"
+)
+
+// Sampled is an ID Generator making the bits traceid.ShouldSample decides on
+// uniformly random, whatever the generator it wraps puts there, so the
+// sampling rate holds for sequential, snowflake and timestamped ids alike.
+type Sampled struct {
+	lockedRand
+	inner IDGenerator
+	rate  float64
+}
+
+// NewSampled returns a Sampled wrapping inner which samples at rate. It
+// overwrites the low 56 bits of Low, traceid.SampleMask, of every id of inner
+// with random bits, wiping whatever inner keeps there: the timestamp and
+// sequence of NewSnowflake64, the counters of NewSequential and
+// NewSequential64 and the increments of NewULID with WithMonotonic, so such
+// ids no longer sort or decode. High and the top 8 bits of Low are kept,
+// which leaves the time of the timestamped generators and NewULID intact.
+func NewSampled(inner IDGenerator, rate float64) *Sampled {
+	return &Sampled{
+		lockedRand: newLockedRand(newConfig(nil)),
+		inner:      inner,
+		rate:       rate,
+	}
+}
+
+// TraceID returns the next id of the wrapped generator with random sampling
+// bits.
+func (s *Sampled) TraceID() traceid.TraceID {
+	id := s.inner.TraceID()
+	s.lock()
+	defer s.unlock()
+	low := id.Low &^ traceid.SampleMask
+	for {
+		id.Low = low | s.uint64()&traceid.SampleMask
+		if id.Low != 0 {
+			return id
+		}
+	}
+}
+
+// Sampled returns the sampling decision for id at the rate of s, see
+// traceid.ShouldSample.
+func (s *Sampled) Sampled(id traceid.TraceID) bool {
+	return traceid.ShouldSample(id, s.rate)
+}
@@ -0,0 +1,48 @@
+package idgenerator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ximply/traceid"
+)
+
+func TestSampledRate(t *testing.T) {
+	const draws = 200000
+	for _, rate := range []float64{0, 0.01, 0.1, 0.5, 1} {
+		s := NewSampled(NewSequential(traceid.TraceID{Low: 1}), rate)
+		sampled := 0
+		for i := 0; i < draws; i++ {
+			if s.Sampled(s.TraceID()) {
+				sampled++
+			}
+		}
+		got := float64(sampled) / draws
+		// five standard deviations of the binomial distribution, which is
+		// zero for the rates 0 and 1
+		tolerance := 5 * math.Sqrt(rate*(1-rate)/draws)
+		if math.Abs(got-rate) > tolerance {
+			t.Errorf("rate %v: sampled %v of %d ids, want within %v", rate, got, draws, tolerance)
+		}
+	}
+}
+
+func TestSampledKeepsHighBits(t *testing.T) {
+	inner := NewFixed(traceid.TraceID{High: 0x4bf92f3577b34da6, Low: 0xa300000000000000})
+	s := NewSampled(inner, 0.5)
+	for i := 0; i < 1000; i++ {
+		id := s.TraceID()
+		if id.High != 0x4bf92f3577b34da6 || id.Low&^traceid.SampleMask != 0xa300000000000000 {
+			t.Fatalf("TraceID() = %v changed bits outside traceid.SampleMask", id)
+		}
+	}
+}
+
+func TestSampledNonZero(t *testing.T) {
+	s := NewSampled(NewFixed(traceid.TraceID{}), 0.5)
+	for i := 0; i < 1000; i++ {
+		if id := s.TraceID(); id.Low == 0 {
+			t.Fatalf("TraceID() = %#v has a zero Low", id)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package idgenerator
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+
+	"github.com/ximply/traceid"
+)
+
+// NewSecureRandom64 returns an ID Generator which can generate 64 bit trace
+// ids from crypto/rand.
+func NewSecureRandom64() IDGenerator {
+	return &secureRandom64{}
+}
+
+// NewSecureRandom128 returns an ID Generator which can generate 128 bit trace
+// ids from crypto/rand.
+func NewSecureRandom128() IDGenerator {
+	return &secureRandom128{}
+}
+
+// secureRandom64 can generate unpredictable 64 bit traceid's.
+type secureRandom64 struct{}
+
+func (s *secureRandom64) TraceID() (id traceid.TraceID) {
+	var b [8]byte
+	for id.Low == 0 {
+		readSecure(b[:])
+		id.Low = binary.BigEndian.Uint64(b[:])
+	}
+	return
+}
+
+// secureRandom128 can generate unpredictable 128 bit traceid's.
+type secureRandom128 struct{}
+
+func (s *secureRandom128) TraceID() (id traceid.TraceID) {
+	var b [16]byte
+	for id.Low == 0 {
+		readSecure(b[:])
+		id.High = binary.BigEndian.Uint64(b[:8])
+		id.Low = binary.BigEndian.Uint64(b[8:])
+	}
+	return
+}
+
+func (s *secureRandom64) SpanID(traceID traceid.TraceID) traceid.SpanID {
+	return secureSpanID()
+}
+
+func (s *secureRandom128) SpanID(traceID traceid.TraceID) traceid.SpanID {
+	return secureSpanID()
+}
+
+// secureSpanID returns a non-zero span id from crypto/rand.
+func secureSpanID() (id traceid.SpanID) {
+	var b [8]byte
+	for id.IsZero() {
+		readSecure(b[:])
+		id = traceid.SpanID(binary.BigEndian.Uint64(b[:]))
+	}
+	return
+}
+
+// readSecure fills b from crypto/rand. Short reads are retried by
+// io.ReadFull; an error after that means the system entropy source is broken,
+// which a trace ID generator has no sensible way to recover from, so it
+// panics.
+func readSecure(b []byte) {
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		panic("idgenerator: reading from crypto/rand failed: " + err.Error())
+	}
+}
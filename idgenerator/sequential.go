@@ -0,0 +1,70 @@
+package idgenerator
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/ximply/traceid"
+)
+
+// Sequential is a deterministic ID Generator for tests. It returns start,
+// start+1, start+2, ... treating the TraceID as a 128 bit number, so Low
+// carries into High.
+type Sequential struct {
+	mtx    sync.Mutex
+	start  traceid.TraceID
+	next   traceid.TraceID
+	issued uint64
+}
+
+// NewSequential returns a Sequential generator starting at start.
+func NewSequential(start traceid.TraceID) *Sequential {
+	return &Sequential{start: start, next: start}
+}
+
+// TraceID returns the next id in the sequence.
+func (s *Sequential) TraceID() (id traceid.TraceID) {
+	s.mtx.Lock()
+	id = s.next
+	s.next.Low++
+	if s.next.Low == 0 {
+		s.next.High++
+	}
+	s.issued++
+	s.mtx.Unlock()
+	return
+}
+
+// Issued returns the number of ids handed out since creation or the last
+// Reset.
+func (s *Sequential) Issued() uint64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.issued
+}
+
+// Reset restarts the sequence at its start value.
+func (s *Sequential) Reset() {
+	s.mtx.Lock()
+	s.next = s.start
+	s.issued = 0
+	s.mtx.Unlock()
+}
+
+// NewSequential64 returns a lock free ID Generator producing the 64 bit ids
+// start, start+1, start+2, ... in Low with High left zero. The counter wraps
+// around after math.MaxUint64, and hands out the zero TraceID when it does.
+func NewSequential64(start uint64) IDGenerator {
+	return &sequential64{next: start}
+}
+
+// sequential64 generates monotonically increasing 64 bit traceid's.
+type sequential64 struct {
+	next uint64 // accessed atomically
+}
+
+func (s *sequential64) TraceID() traceid.TraceID {
+	return traceid.TraceID{
+		Low: atomic.AddUint64(&s.next, 1) - 1,
+	}
+}
@@ -0,0 +1,62 @@
+package idgenerator
+
+import (
+	"github.com/kataras/iris/core/errors"
+	"github.com/ximply/traceid"
+)
+
+// sharding errors
+var (
+	ErrShardBits = errors.New("shard width %d out of range [1, 16]")
+	ErrShardID   = errors.New("shard id %d does not fit in %d bits")
+)
+
+// NewSharded returns an ID Generator which takes ids from inner and
+// overwrites the top bits bits of High with shardID, so any hop can route a
+// trace with traceid.ShardOf instead of hashing its id. For bits w the layout
+// is High = shardID<<(64-w) | (inner High & (1<<(64-w) - 1)), Low untouched.
+func NewSharded(shardID uint16, bits uint, inner IDGenerator) (IDGenerator, error) {
+	if bits < 1 || bits > 16 {
+		return nil, ErrShardBits.Format(bits)
+	}
+	if uint64(shardID) >= 1<<bits {
+		return nil, ErrShardID.Format(shardID, bits)
+	}
+	return &prefixed{
+		inner:  inner,
+		prefix: uint64(shardID) << (64 - bits),
+		mask:   ^uint64(0) >> bits,
+	}, nil
+}
+
+// prefixed stamps a fixed prefix into the top bits of High.
+type prefixed struct {
+	inner  IDGenerator
+	prefix uint64
+	mask   uint64
+}
+
+func (p *prefixed) TraceID() traceid.TraceID {
+	id := p.inner.TraceID()
+	id.High = p.prefix | id.High&p.mask
+	return id
+}
+
+// ErrNamespaceBits is returned for namespace prefixes wider than 32 bits.
+var ErrNamespaceBits = errors.New("namespace width %d out of range [1, 32]")
+
+// NewNamespaced returns an ID Generator which takes ids from inner and
+// overwrites the top bits bits of High with traceid.NamespacePrefix of
+// namespace, so the namespace can be told from the id alone with
+// traceid.NamespaceBitsOf. Use at least 16 bits to keep namespaces from
+// sharing a prefix.
+func NewNamespaced(namespace string, bits uint, inner IDGenerator) (IDGenerator, error) {
+	if bits < 1 || bits > 32 {
+		return nil, ErrNamespaceBits.Format(bits)
+	}
+	return &prefixed{
+		inner:  inner,
+		prefix: traceid.NamespacePrefix(namespace, bits) << (64 - bits),
+		mask:   ^uint64(0) >> bits,
+	}, nil
+}
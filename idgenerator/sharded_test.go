@@ -0,0 +1,51 @@
+package idgenerator
+
+import (
+	"testing"
+
+	"github.com/ximply/traceid"
+)
+
+func TestNamespaced(t *testing.T) {
+	inner := traceid.TraceID{High: 0x0123456789abcdef, Low: 0xfedcba9876543210}
+	for _, bits := range []uint{1, 16, 32} {
+		gen, err := NewNamespaced("tenant-a", bits, NewFixed(inner))
+		if err != nil {
+			t.Fatal(err)
+		}
+		id := gen.TraceID()
+		if got, want := traceid.NamespaceBitsOf(id, bits), traceid.NamespacePrefix("tenant-a", bits); got != want {
+			t.Errorf("%d bits: NamespaceBitsOf = %#x, want %#x", bits, got, want)
+		}
+		mask := ^uint64(0) >> bits
+		if id.High&mask != inner.High&mask || id.Low != inner.Low {
+			t.Errorf("%d bits: %v changed bits of the inner id %v", bits, id, inner)
+		}
+	}
+	gen, _ := NewNamespaced("tenant-a", 16, NewFixed(inner))
+	if got, want := gen.TraceID().High, uint64(0xcac5456789abcdef); got != want {
+		t.Errorf("High = %#x, want %#x", got, want)
+	}
+	for _, bits := range []uint{0, 33} {
+		if _, err := NewNamespaced("tenant-a", bits, NewFixed(inner)); err == nil || !ErrNamespaceBits.Equal(err) {
+			t.Errorf("NewNamespaced with %d bits error = %v, want ErrNamespaceBits", bits, err)
+		}
+	}
+}
+
+func TestSharded(t *testing.T) {
+	inner := traceid.TraceID{High: ^uint64(0), Low: 1}
+	gen, err := NewSharded(5, 4, NewFixed(inner))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := gen.TraceID(), (traceid.TraceID{High: 0x5fffffffffffffff, Low: 1}); got != want {
+		t.Errorf("TraceID() = %v, want %v", got, want)
+	}
+	if _, err := NewSharded(16, 4, NewFixed(inner)); err == nil || !ErrShardID.Equal(err) {
+		t.Errorf("NewSharded(16, 4) error = %v, want ErrShardID", err)
+	}
+	if _, err := NewSharded(1, 17, NewFixed(inner)); err == nil || !ErrShardBits.Equal(err) {
+		t.Errorf("NewSharded(1, 17) error = %v, want ErrShardBits", err)
+	}
+}
@@ -0,0 +1,92 @@
+package idgenerator
+
+import (
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/kataras/iris/core/errors"
+	"github.com/ximply/traceid"
+)
+
+// Snowflake layout of TraceID.Low, High is always zero:
+//
+//	bit  63:     zero
+//	bits 62-22:  milliseconds since the epoch (41 bits, about 69 years)
+//	bits 21-12:  worker id (10 bits)
+//	bits 11-0:   sequence within the millisecond (12 bits)
+const (
+	snowflakeWorkerBits   = 10
+	snowflakeSequenceBits = 12
+	snowflakeMaxWorker    = 1<<snowflakeWorkerBits - 1
+	snowflakeMaxSequence  = 1<<snowflakeSequenceBits - 1
+)
+
+// SnowflakeEpoch is the epoch of the snowflake generator unless WithEpoch is
+// given.
+var SnowflakeEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ErrInvalidWorkerID is returned for snowflake worker ids above 1023.
+var ErrInvalidWorkerID = errors.New("snowflake worker id %d out of range [0, 1023]")
+
+// NewSnowflake64 returns an ID Generator which packs a millisecond timestamp,
+// workerID and a per millisecond sequence into 64 bit traceid's. Ids are
+// unique across generators with different worker ids. When the 4096 ids of a
+// millisecond are used up the generator waits for the next millisecond, and
+// when the clock steps back it keeps counting in the last millisecond it saw,
+// so small clock adjustments never produce duplicates. The generator takes no
+// lock. The WithClock and WithEpoch options are honored.
+func NewSnowflake64(workerID uint16, opts ...Option) (IDGenerator, error) {
+	if workerID > snowflakeMaxWorker {
+		return nil, ErrInvalidWorkerID.Format(workerID)
+	}
+	c := newConfig(opts)
+	if !c.epochSet {
+		c.epoch = SnowflakeEpoch
+	}
+	return &snowflake64{
+		clock:  c.epochClock(),
+		worker: uint64(workerID) << snowflakeSequenceBits,
+	}, nil
+}
+
+// NewSnowflake is like NewSnowflake64 with the given epoch, for wiring code
+// with a constant machine id. It panics if machineID is above 1023 or epoch
+// lies in the future.
+func NewSnowflake(machineID uint16, epoch time.Time) IDGenerator {
+	gen, err := NewSnowflake64(machineID, WithEpoch(epoch))
+	if err != nil {
+		panic("idgenerator: " + err.Error())
+	}
+	return gen
+}
+
+// snowflake64 can generate 64 bit traceid's unique across workers
+type snowflake64 struct {
+	state  uint64 // ms<<12 | sequence of the last id, accessed atomically
+	clock  epochClock
+	worker uint64
+}
+
+func (s *snowflake64) TraceID() traceid.TraceID {
+	for {
+		old := atomic.LoadUint64(&s.state)
+		last := int64(old >> snowflakeSequenceBits)
+		ms := s.clock.millis()
+		next := uint64(ms) << snowflakeSequenceBits
+		if ms <= last {
+			if old&snowflakeMaxSequence == snowflakeMaxSequence {
+				// sequence exhausted, wait for the clock to move on
+				runtime.Gosched()
+				continue
+			}
+			next = old + 1
+		}
+		if atomic.CompareAndSwapUint64(&s.state, old, next) {
+			return traceid.TraceID{
+				Low: next>>snowflakeSequenceBits<<(snowflakeWorkerBits+snowflakeSequenceBits) |
+					s.worker | next&snowflakeMaxSequence,
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package idgenerator
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ximply/traceid"
+)
+
+func TestSnowflakeLayout(t *testing.T) {
+	now := SnowflakeEpoch.Add(1234567 * time.Millisecond)
+	gen, err := NewSnowflake64(5, WithClock(fixedClock(now)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for seq := uint64(0); seq < 3; seq++ {
+		want := traceid.TraceID{Low: 1234567<<22 | 5<<12 | seq}
+		if got := gen.TraceID(); got != want {
+			t.Errorf("id %d = %#x, want %#x", seq, got.Low, want.Low)
+		}
+	}
+	epoch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	gen = NewSnowflake(1023, epoch)
+	if got := gen.TraceID().Low >> 12 & snowflakeMaxWorker; got != 1023 {
+		t.Errorf("worker id = %d, want 1023", got)
+	}
+}
+
+func TestSnowflakeWorkerID(t *testing.T) {
+	if _, err := NewSnowflake64(1024); err == nil || !ErrInvalidWorkerID.Equal(err) {
+		t.Errorf("NewSnowflake64(1024) error = %v, want ErrInvalidWorkerID", err)
+	}
+	mustPanic(t, "NewSnowflake(1024)", func() { NewSnowflake(1024, SnowflakeEpoch) })
+	mustPanic(t, "NewSnowflake with a future epoch", func() { NewSnowflake(1, time.Now().Add(time.Hour)) })
+}
+
+func TestSnowflakeUnique(t *testing.T) {
+	const perWorker, goroutines, n = 2, 4, 5000
+	var mtx sync.Mutex
+	seen := make(map[traceid.TraceID]bool)
+	var wg sync.WaitGroup
+	for worker := uint16(0); worker < perWorker; worker++ {
+		gen, err := NewSnowflake64(worker)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for g := 0; g < goroutines; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ids := make([]traceid.TraceID, n)
+				for i := range ids {
+					ids[i] = gen.TraceID()
+				}
+				mtx.Lock()
+				defer mtx.Unlock()
+				for _, id := range ids {
+					if seen[id] {
+						t.Errorf("duplicate id %#x", id.Low)
+					}
+					seen[id] = true
+				}
+			}()
+		}
+	}
+	wg.Wait()
+}
+
+func TestSnowflakeClock(t *testing.T) {
+	// the clock moves on by one millisecond every 5000 reads and steps back
+	// by two milliseconds after 20000 reads, forcing both sequence exhaustion
+	// and a clock going backwards
+	var mtx sync.Mutex
+	reads := 0
+	base := SnowflakeEpoch.Add(time.Hour)
+	clock := func() time.Time {
+		mtx.Lock()
+		defer mtx.Unlock()
+		reads++
+		now := base.Add(time.Duration(reads/5000) * time.Millisecond)
+		if reads > 20000 {
+			now = now.Add(-2 * time.Millisecond)
+		}
+		return now
+	}
+	gen, err := NewSnowflake64(7, WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := gen.TraceID()
+	for i := 0; i < 30000; i++ {
+		id := gen.TraceID()
+		if id.Low <= last.Low {
+			t.Fatalf("id %d = %#x not above %#x", i, id.Low, last.Low)
+		}
+		if id.Low>>12&snowflakeMaxWorker != 7 {
+			t.Fatalf("id %d = %#x lost the worker id", i, id.Low)
+		}
+		last = id
+	}
+}
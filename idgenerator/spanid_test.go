@@ -0,0 +1,35 @@
+package idgenerator
+
+import (
+	"testing"
+
+	"github.com/ximply/traceid"
+)
+
+func TestSpanIDs(t *testing.T) {
+	for name, gen := range map[string]IDGenerator{
+		"Random64":          NewRandom64(),
+		"Random128":         NewRandom128(),
+		"RandomTimestamped": NewRandomTimestamped(),
+		"SecureRandom64":    NewSecureRandom64(),
+		"SecureRandom128":   NewSecureRandom128(),
+	} {
+		sg, ok := gen.(SpanIDGenerator)
+		if !ok {
+			t.Errorf("%s is no SpanIDGenerator", name)
+			continue
+		}
+		tid := gen.TraceID()
+		seen := make(map[traceid.SpanID]bool)
+		for i := 0; i < 10000; i++ {
+			id := sg.SpanID(tid)
+			if id.IsZero() {
+				t.Fatalf("%s: SpanID() is zero", name)
+			}
+			if seen[id] {
+				t.Fatalf("%s: SpanID() = %v twice", name, id)
+			}
+			seen[id] = true
+		}
+	}
+}
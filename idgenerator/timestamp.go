@@ -0,0 +1,66 @@
+package idgenerator
+
+import (
+	"math"
+	"time"
+
+	"github.com/ximply/traceid"
+)
+
+// maxClockSkew is how far in the future of the local clock a decoded
+// timestamp may lie before the id is considered not to be timestamped.
+const maxClockSkew = 24 * time.Hour
+
+// TimestampOf returns the time, to the second, at which a traceid was
+// generated by NewRandomTimestamped. It reports false for ids which cannot have
+// come from that generator: a zero timestamp, or one more than a day ahead of
+// the clock. The WithEpoch and WithClock options are honored.
+func TimestampOf(id traceid.TraceID, opts ...Option) (time.Time, bool) {
+	c := newConfig(opts)
+	sec := int64(id.High >> 32)
+	if sec == 0 {
+		return time.Time{}, false
+	}
+	t := time.Unix(c.epoch.Unix()+sec, 0)
+	if t.After(c.clock().Add(maxClockSkew)) {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// LowerBound returns the smallest traceid NewRandomTimestamped or
+// NewMonotonicTimestamped can generate during the second of t, for scanning a
+// store keyed by those ids. The WithEpoch option is honored.
+func LowerBound(t time.Time, opts ...Option) traceid.TraceID {
+	c := newConfig(opts)
+	sec := uint64(t.Unix() - c.epoch.Unix())
+	return traceid.TraceID{High: sec << 32}
+}
+
+// UpperBound returns the largest traceid NewRandomTimestamped or
+// NewMonotonicTimestamped can generate during the second of t. The WithEpoch
+// option is honored.
+func UpperBound(t time.Time, opts ...Option) traceid.TraceID {
+	c := newConfig(opts)
+	sec := uint64(t.Unix() - c.epoch.Unix())
+	return traceid.TraceID{High: sec<<32 | math.MaxUint32, Low: math.MaxUint64}
+}
+
+// MillisLowerBound returns the smallest traceid NewRandomTimestampedMillis can
+// generate during the millisecond of t. The WithEpoch option is honored.
+func MillisLowerBound(t time.Time, opts ...Option) traceid.TraceID {
+	ms := uint64(epochClock{now: constantTime(t), epoch: newConfig(opts).epoch}.millis())
+	return traceid.TraceID{High: ms << 16}
+}
+
+// MillisUpperBound returns the largest traceid NewRandomTimestampedMillis can
+// generate during the millisecond of t. The WithEpoch option is honored.
+func MillisUpperBound(t time.Time, opts ...Option) traceid.TraceID {
+	ms := uint64(epochClock{now: constantTime(t), epoch: newConfig(opts).epoch}.millis())
+	return traceid.TraceID{High: ms<<16 | 0xffff, Low: math.MaxUint64}
+}
+
+// constantTime returns a clock which is stopped at t.
+func constantTime(t time.Time) func() time.Time {
+	return func() time.Time { return t }
+}
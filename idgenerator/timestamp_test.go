@@ -0,0 +1,122 @@
+package idgenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ximply/traceid"
+)
+
+func fixedClock(t time.Time) func() time.Time {
+	return func() time.Time { return t }
+}
+
+func TestEpochRoundTrip(t *testing.T) {
+	now := time.Date(2024, 5, 17, 13, 37, 42, 123456789, time.UTC)
+	epoch := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, opts := range [][]Option{
+		{WithClock(fixedClock(now))},
+		{WithClock(fixedClock(now)), WithEpoch(epoch)},
+	} {
+		id := NewRandomTimestamped(opts...).TraceID()
+		got, ok := TimestampOf(id, opts...)
+		if want := now.Truncate(time.Second); !ok || !got.Equal(want) {
+			t.Errorf("TimestampOf = %v, %v, want %v", got, ok, want)
+		}
+		ms := MillisOf(NewRandomTimestampedMillis(opts...).TraceID(), opts...)
+		if want := now.Truncate(time.Millisecond); !ms.Equal(want) {
+			t.Errorf("MillisOf = %v, want %v", ms, want)
+		}
+	}
+
+	id := NewRandomTimestamped(WithClock(fixedClock(now)), WithEpoch(epoch)).TraceID()
+	if got, want := id.High>>32, uint64(now.Sub(epoch)/time.Second); got != want {
+		t.Errorf("encoded seconds = %d, want %d since the epoch", got, want)
+	}
+	if got, ok := TimestampOf(id, WithClock(fixedClock(now))); ok && got.Equal(now.Truncate(time.Second)) {
+		t.Error("TimestampOf without the epoch decoded the custom epoch id")
+	}
+}
+
+func TestRandomTimestampedClock(t *testing.T) {
+	now := time.Date(2024, 5, 17, 13, 37, 42, 0, time.UTC)
+	gen := NewRandomTimestamped(WithClock(func() time.Time { return now }))
+	a, b := gen.TraceID(), gen.TraceID()
+	if a == b {
+		t.Errorf("two ids of the same instant are equal: %v", a)
+	}
+	if a.High>>32 != b.High>>32 {
+		t.Errorf("ids of the same instant carry different seconds: %v, %v", a, b)
+	}
+	for _, step := range []time.Duration{time.Second, time.Minute, 24 * time.Hour} {
+		t1 := gen.TraceID()
+		now = now.Add(step)
+		t2 := gen.TraceID()
+		if !t1.Less(t2) || t2.Compare(t1) != 1 {
+			t.Errorf("id at t1 %v does not sort before id at t1+%v %v", t1, step, t2)
+		}
+	}
+}
+
+func TestDefaultEpochUnchanged(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	id := NewRandomTimestamped(WithClock(fixedClock(now))).TraceID()
+	if got := id.High >> 32; got != 1700000000 {
+		t.Errorf("encoded seconds = %d, want Unix seconds", got)
+	}
+}
+
+func mustPanic(t *testing.T, name string, f func()) {
+	t.Helper()
+	defer func() {
+		if recover() == nil {
+			t.Errorf("%s did not panic", name)
+		}
+	}()
+	f()
+}
+
+func TestEpochRejected(t *testing.T) {
+	now := time.Now()
+	future := WithEpoch(now.Add(time.Hour))
+	mustPanic(t, "future epoch", func() { NewRandomTimestamped(future) })
+	mustPanic(t, "future millis epoch", func() { NewRandomTimestampedMillis(future) })
+
+	past := WithEpoch(now.Add(-time.Hour))
+	mustPanic(t, "NewULID", func() { NewULID(past) })
+	mustPanic(t, "NewUUIDv7", func() { NewUUIDv7(past) })
+	mustPanic(t, "NewXRayCompatible", func() { NewXRayCompatible(past) })
+}
+
+func TestBounds(t *testing.T) {
+	now := time.Date(2024, 5, 17, 13, 37, 42, 123456789, time.UTC)
+	epoch := WithEpoch(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	type bounds func(time.Time, ...Option) traceid.TraceID
+	tests := []struct {
+		name         string
+		gen          func(...Option) IDGenerator
+		lower, upper bounds
+		step         time.Duration
+	}{
+		{"RandomTimestamped", NewRandomTimestamped, LowerBound, UpperBound, time.Second},
+		{"MonotonicTimestamped", NewMonotonicTimestamped, LowerBound, UpperBound, time.Second},
+		{"RandomTimestampedMillis", NewRandomTimestampedMillis, MillisLowerBound, MillisUpperBound, time.Millisecond},
+	}
+	for _, tt := range tests {
+		for _, opts := range [][]Option{nil, {epoch}} {
+			lower, upper := tt.lower(now, opts...), tt.upper(now, opts...)
+			gen := tt.gen(append(opts, WithClock(fixedClock(now)))...)
+			for i := 0; i < 1000; i++ {
+				id := gen.TraceID()
+				if id.Less(lower) || upper.Less(id) {
+					t.Fatalf("%s %v: %v outside [%v, %v]", tt.name, opts, id, lower, upper)
+				}
+			}
+			before := tt.gen(append(opts, WithClock(fixedClock(now.Add(-tt.step))))...).TraceID()
+			after := tt.gen(append(opts, WithClock(fixedClock(now.Add(tt.step))))...).TraceID()
+			if !before.Less(lower) || !upper.Less(after) {
+				t.Errorf("%s %v: ids of the neighbouring %v fall inside [%v, %v]", tt.name, opts, tt.step, lower, upper)
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package idgenerator
+
+import (
+	"runtime"
+
+	"github.com/ximply/traceid"
+)
+
+// NewULID returns an ID Generator producing ULID compatible 128 bit
+// traceid's: a 48 bit Unix millisecond timestamp in the upper bits of High
+// followed by 80 random bits, so that High is (ms << 16 | 16 random bits) and
+// Low is random and never zero. Ids of different milliseconds sort by time
+// with TraceID.Less; render them with TraceID.ULIDString. With WithMonotonic,
+// ids generated within the same millisecond increase by one, skipping a zero
+// Low; if the random part would overflow the generator waits for the next
+// millisecond.
+func NewULID(opts ...Option) IDGenerator {
+	c := newConfig(opts)
+	c.unixEpochOnly("NewULID")
+	return &ulid{
+		lockedRand: newLockedRand(c),
+		clock:      c.epochClock(),
+		monotonic:  c.monotonic,
+	}
+}
+
+// ulid can generate ULID compatible traceid's
+type ulid struct {
+	lockedRand
+	clock     epochClock
+	monotonic bool
+	last      traceid.TraceID
+}
+
+func (u *ulid) TraceID() (id traceid.TraceID) {
+	u.lock()
+	defer u.unlock()
+	ms := uint64(u.clock.millis())
+	if u.monotonic && ms <= u.last.High>>16 {
+		id = u.last
+		id.Low++
+		if id.Low == 0 {
+			// carry into High and skip the zero Low
+			id.High++
+			id.Low++
+		}
+		if id.High>>16 == u.last.High>>16 {
+			u.last = id
+			return
+		}
+		// the 80 random bits overflowed, continue in the next millisecond
+		for ms <= u.last.High>>16 {
+			runtime.Gosched()
+			ms = uint64(u.clock.millis())
+		}
+	}
+	id = traceid.TraceID{
+		High: ms<<16 | uint64(u.uint32()&0xffff),
+		Low:  u.nonZeroUint64(),
+	}
+	u.last = id
+	return
+}
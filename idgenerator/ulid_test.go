@@ -0,0 +1,58 @@
+package idgenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ximply/traceid"
+)
+
+func TestULID(t *testing.T) {
+	now := time.Date(2024, 5, 17, 13, 37, 42, 123456789, time.UTC)
+	gen := NewULID(WithClock(fixedClock(now)))
+	for i := 0; i < 100; i++ {
+		id := gen.TraceID()
+		if got, want := int64(id.High>>16), now.UnixNano()/1e6; got != want {
+			t.Fatalf("timestamp = %d, want %d", got, want)
+		}
+		parsed, err := traceid.ParseULID(id.ULIDString())
+		if err != nil || parsed != id {
+			t.Fatalf("ParseULID(%q) = %v, %v, want %v", id.ULIDString(), parsed, err, id)
+		}
+	}
+}
+
+func TestULIDMonotonic(t *testing.T) {
+	now := time.Date(2024, 5, 17, 13, 37, 42, 0, time.UTC)
+	gen := NewULID(WithClock(fixedClock(now)), WithMonotonic())
+	last := gen.TraceID()
+	for i := 0; i < 1000; i++ {
+		id := gen.TraceID()
+		want := last
+		want.Low++
+		if want.Low == 0 {
+			want.High++
+		}
+		if id != want {
+			t.Fatalf("id %d = %v, want %v", i, id, want)
+		}
+		if id.ULIDString() <= last.ULIDString() {
+			t.Fatalf("ULIDString %q does not sort after %q", id.ULIDString(), last.ULIDString())
+		}
+		last = id
+	}
+}
+
+func TestULIDMonotonicSkipsZeroLow(t *testing.T) {
+	now := time.Date(2024, 5, 17, 13, 37, 42, 0, time.UTC)
+	src := &scriptedSource{vals: []uint64{0x1234 << 32, ^uint64(0)}}
+	gen := NewULID(WithClock(fixedClock(now)), WithSource(src), WithMonotonic())
+	first := gen.TraceID()
+	if first.Low != ^uint64(0) {
+		t.Fatalf("first id = %v, want an all ones Low", first)
+	}
+	want := traceid.TraceID{High: first.High + 1, Low: 1}
+	if got := gen.TraceID(); got != want {
+		t.Errorf("id after the carry = %v, want %v", got, want)
+	}
+}
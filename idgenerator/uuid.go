@@ -0,0 +1,90 @@
+package idgenerator
+
+import (
+	"runtime"
+
+	"github.com/ximply/traceid"
+)
+
+// NewUUIDv4 returns an ID Generator producing random traceid's which are
+// valid version 4 UUIDs with the RFC 4122 variant when rendered with
+// TraceID.UUIDString, leaving 122 random bits.
+func NewUUIDv4(opts ...Option) IDGenerator {
+	return &uuidV4{lockedRand: newLockedRand(newConfig(opts))}
+}
+
+// setUUIDVersion sets the version nibble and the RFC 4122 variant bits.
+func setUUIDVersion(id *traceid.TraceID, version uint64) {
+	id.High = id.High&^0xf000 | version<<12
+	id.Low = id.Low&^(3<<62) | 2<<62
+}
+
+// uuidV4 can generate version 4 UUID traceid's
+type uuidV4 struct {
+	lockedRand
+}
+
+func (u *uuidV4) TraceID() (id traceid.TraceID) {
+	u.lock()
+	id = traceid.TraceID{
+		High: u.uint64(),
+		Low:  u.uint64(),
+	}
+	u.unlock()
+	setUUIDVersion(&id, 4)
+	return
+}
+
+// NewUUIDv7 returns an ID Generator producing time ordered traceid's which are
+// valid version 7 UUIDs: a 48 bit Unix millisecond timestamp in the upper
+// bits of High, the version nibble, 12 random bits, the RFC 4122 variant and
+// 62 random bits in Low. With WithMonotonic, ids generated within the same
+// millisecond count up from the previous one through the 74 random bits; if
+// they run out the generator waits for the next millisecond.
+func NewUUIDv7(opts ...Option) IDGenerator {
+	c := newConfig(opts)
+	c.unixEpochOnly("NewUUIDv7")
+	return &uuidV7{
+		lockedRand: newLockedRand(c),
+		clock:      c.epochClock(),
+		monotonic:  c.monotonic,
+	}
+}
+
+// uuidV7 can generate version 7 UUID traceid's
+type uuidV7 struct {
+	lockedRand
+	clock     epochClock
+	monotonic bool
+	last      traceid.TraceID
+}
+
+func (u *uuidV7) TraceID() (id traceid.TraceID) {
+	const low62 = 1<<62 - 1
+	u.lock()
+	defer u.unlock()
+	ms := uint64(u.clock.millis())
+	if last := u.last.High >> 16; u.monotonic && ms <= last {
+		randA, randB := u.last.High&0xfff, u.last.Low&low62+1
+		if randB > low62 {
+			randA, randB = randA+1, 0
+		}
+		if randA <= 0xfff {
+			id = traceid.TraceID{High: last<<16 | randA, Low: randB}
+			setUUIDVersion(&id, 7)
+			u.last = id
+			return
+		}
+		for ms <= last {
+			runtime.Gosched()
+			ms = uint64(u.clock.millis())
+		}
+	}
+	id = traceid.TraceID{
+		High: ms<<16 | uint64(u.uint32()&0xfff),
+		Low:  u.uint64(),
+	}
+	setUUIDVersion(&id, 7)
+	u.last = id
+	return
+}
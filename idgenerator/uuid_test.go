@@ -0,0 +1,91 @@
+package idgenerator
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/ximply/traceid"
+)
+
+// rfc9562 is the time of the UUIDv7 example of RFC 9562, appendix A.6.
+var rfc9562 = time.Unix(0, 0x017f22e279b0*int64(time.Millisecond))
+
+func TestUUIDv7Vectors(t *testing.T) {
+	src := &scriptedSource{vals: []uint64{0xcc3 << 32, 0x98c4dc0c0c07398f}}
+	gen := NewUUIDv7(WithClock(fixedClock(rfc9562)), WithSource(src), WithMonotonic())
+	for _, want := range []string{
+		"017f22e2-79b0-7cc3-98c4-dc0c0c07398f",
+		"017f22e2-79b0-7cc3-98c4-dc0c0c073990",
+		"017f22e2-79b0-7cc3-98c4-dc0c0c073991",
+	} {
+		if got := gen.TraceID().UUIDString(); got != want {
+			t.Errorf("UUIDString() = %s, want %s", got, want)
+		}
+	}
+
+	// the random bits are masked to make room for version and variant
+	src = &scriptedSource{vals: []uint64{^uint64(0), ^uint64(0)}}
+	gen = NewUUIDv7(WithClock(fixedClock(rfc9562)), WithSource(src))
+	if got, want := gen.TraceID().UUIDString(), "017f22e2-79b0-7fff-bfff-ffffffffffff"; got != want {
+		t.Errorf("UUIDString() = %s, want %s", got, want)
+	}
+	src = &scriptedSource{vals: []uint64{0, 0}}
+	gen = NewUUIDv7(WithClock(fixedClock(rfc9562)), WithSource(src))
+	if got, want := gen.TraceID().UUIDString(), "017f22e2-79b0-7000-8000-000000000000"; got != want {
+		t.Errorf("UUIDString() = %s, want %s", got, want)
+	}
+}
+
+func TestUUIDv7Monotonic(t *testing.T) {
+	// the 74 random bits are all set, so the next id of the millisecond
+	// would overflow them and has to wait for the clock, which moves on after
+	// the reads of the constructor and the first two ids
+	var reads int
+	clock := func() time.Time {
+		reads++
+		if reads > 3 {
+			return rfc9562.Add(time.Millisecond)
+		}
+		return rfc9562
+	}
+	src := &scriptedSource{vals: []uint64{0xfff << 32, ^uint64(0), 0, 0}}
+	gen := NewUUIDv7(WithClock(clock), WithSource(src), WithMonotonic())
+	for _, want := range []string{
+		"017f22e2-79b0-7fff-bfff-ffffffffffff",
+		"017f22e2-79b1-7000-8000-000000000000",
+	} {
+		if got := gen.TraceID().UUIDString(); got != want {
+			t.Errorf("UUIDString() = %s, want %s", got, want)
+		}
+	}
+	if reads != 4 {
+		t.Errorf("clock read %d times, want 4", reads)
+	}
+}
+
+func TestUUIDv7Sorted(t *testing.T) {
+	now := rfc9562
+	gen := NewUUIDv7(WithClock(func() time.Time { return now }), WithMonotonic())
+	var ids []string
+	for i := 0; i < 1000; i++ {
+		if i%10 == 0 {
+			now = now.Add(time.Millisecond)
+		}
+		id := gen.TraceID()
+		if id.High>>12&0xf != 7 || id.Low>>62 != 2 {
+			t.Fatalf("%s has no version 7 and RFC 4122 variant", id.UUIDString())
+		}
+		ids = append(ids, id.UUIDString())
+	}
+	if !sort.StringsAreSorted(ids) {
+		t.Error("UUIDStrings of consecutive ids do not sort")
+	}
+}
+
+func TestUUIDv4(t *testing.T) {
+	gen := NewUUIDv4(WithSource(&scriptedSource{vals: []uint64{^uint64(0), 0}}))
+	if got, want := gen.TraceID(), (traceid.TraceID{High: 0xffffffffffff4fff, Low: 0x8000000000000000}); got != want {
+		t.Errorf("TraceID() = %s, want %s", got.UUIDString(), want.UUIDString())
+	}
+}
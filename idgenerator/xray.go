@@ -0,0 +1,11 @@
+package idgenerator
+
+// NewXRayCompatible returns an ID Generator whose ids carry the Unix seconds
+// in the upper 32 bits of High, the layout of NewRandomTimestamped with the
+// epoch fixed to the Unix epoch, so TraceID.XRayString with a zero time and
+// traceid.ParseXRay convert them without loss.
+func NewXRayCompatible(opts ...Option) IDGenerator {
+	c := newConfig(opts)
+	c.unixEpochOnly("NewXRayCompatible")
+	return &randomTimestamped{lockedRand: newLockedRand(c), clock: c.epochClock()}
+}
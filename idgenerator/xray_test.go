@@ -0,0 +1,24 @@
+package idgenerator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ximply/traceid"
+)
+
+func TestXRayCompatible(t *testing.T) {
+	now := time.Unix(1465510280, 0)
+	gen := NewXRayCompatible(WithClock(fixedClock(now)))
+	for i := 0; i < 100; i++ {
+		id := gen.TraceID()
+		s := id.XRayString(time.Time{})
+		if s[:11] != "1-5759e988-" {
+			t.Fatalf("XRayString() = %q, want the time of the clock", s)
+		}
+		parsed, at, err := traceid.ParseXRay(s)
+		if err != nil || parsed != id || !at.Equal(now) {
+			t.Fatalf("ParseXRay(%q) = %v, %v, %v, want %v, %v", s, parsed, at, err, id, now)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package traceid
+
+import (
+	"github.com/kataras/iris/core/errors"
+)
+
+// MessagePack format bytes
+const (
+	msgpackNil  = 0xc0
+	msgpackBin8 = 0xc4
+)
+
+// ErrMsgpack is returned for MessagePack input other than a 16 byte bin8.
+var ErrMsgpack = errors.New("want a 16 byte msgpack bin8 value for a TraceID")
+
+// MarshalMsgpack implements msgpack.Marshaler of github.com/vmihailenco/msgpack
+// without depending on it: the TraceID is encoded as a bin8 value holding the
+// 16 bytes of Bytes.
+func (t TraceID) MarshalMsgpack() ([]byte, error) {
+	b := t.Bytes()
+	return append([]byte{msgpackBin8, 16}, b[:]...), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler, reading what MarshalMsgpack
+// writes. A msgpack nil yields the zero TraceID.
+func (t *TraceID) UnmarshalMsgpack(data []byte) error {
+	if len(data) == 1 && data[0] == msgpackNil {
+		*t = TraceID{}
+		return nil
+	}
+	if len(data) != 18 || data[0] != msgpackBin8 || data[1] != 16 {
+		return ErrMsgpack
+	}
+	return t.UnmarshalBinary(data[2:])
+}
@@ -0,0 +1,35 @@
+package traceid
+
+import (
+	"hash/fnv"
+)
+
+// NamespacePrefix returns the bits bit prefix idgenerator.NewNamespaced
+// stamps into ids of namespace: the top bits bits of the 64 bit FNV-1a hash of
+// the namespace passed through the splitmix64 finalizer, which spreads names
+// differing only in their last byte over the top bits. Different namespaces
+// may share a prefix, with probability 2^-bits for any two of them. bits is
+// clamped to [0, 64]. This mapping is part of the API and will not change.
+func NamespacePrefix(namespace string, bits uint) uint64 {
+	if bits == 0 {
+		return 0
+	}
+	if bits > 64 {
+		bits = 64
+	}
+	h := fnv.New64a()
+	h.Write([]byte(namespace))
+	return splitmix64(h.Sum64()) >> (64 - bits)
+}
+
+// NamespaceBitsOf returns the namespace prefix stored in the top bits bits of
+// High, to be compared with NamespacePrefix. bits is clamped to [0, 64].
+func NamespaceBitsOf(id TraceID, bits uint) uint64 {
+	if bits == 0 {
+		return 0
+	}
+	if bits > 64 {
+		bits = 64
+	}
+	return id.High >> (64 - bits)
+}
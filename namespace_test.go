@@ -0,0 +1,46 @@
+package traceid
+
+import "testing"
+
+func TestNamespacePrefixGolden(t *testing.T) {
+	tests := []struct {
+		namespace string
+		bits      uint
+		want      uint64
+	}{
+		{"", 64, 0xf52a15e9a9b5e89b},
+		{"tenant-a", 64, 0xcac5cf1c9ddded39},
+		{"tenant-a", 32, 0xcac5cf1c},
+		{"tenant-a", 16, 0xcac5},
+		{"tenant-b", 16, 0x7429},
+		{"payments", 16, 0x6570},
+		{"payments", 1, 0},
+		{"payments", 0, 0},
+		{"payments", 80, 0x6570b2129171541f},
+	}
+	for _, tt := range tests {
+		if got := NamespacePrefix(tt.namespace, tt.bits); got != tt.want {
+			t.Errorf("NamespacePrefix(%q, %d) = %#x, want %#x", tt.namespace, tt.bits, got, tt.want)
+		}
+	}
+}
+
+func TestNamespacePrefixCollision(t *testing.T) {
+	// prefixes are short hashes and may collide, which is documented as
+	// acceptable: these two share their top 16 bits
+	if a, b := NamespacePrefix("tenant-30", 16), NamespacePrefix("tenant-163", 16); a != b {
+		t.Errorf("prefixes %#x and %#x differ, want the known collision", a, b)
+	}
+	if a, b := NamespacePrefix("tenant-30", 32), NamespacePrefix("tenant-163", 32); a == b {
+		t.Errorf("prefixes %#x collide at 32 bits too", a)
+	}
+}
+
+func TestNamespaceBitsOf(t *testing.T) {
+	id := TraceID{High: 0xcac5123456789abc, Low: 1}
+	for bits, want := range map[uint]uint64{0: 0, 16: 0xcac5, 64: 0xcac5123456789abc, 100: 0xcac5123456789abc} {
+		if got := NamespaceBitsOf(id, bits); got != want {
+			t.Errorf("NamespaceBitsOf(%v, %d) = %#x, want %#x", id, bits, got, want)
+		}
+	}
+}
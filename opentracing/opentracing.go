@@ -0,0 +1,85 @@
+/*
+Package opentracing bridges traceid and the carrier formats of
+opentracing-go, so middleware written against the OpenTracing API keeps
+working after moving off an OpenTracing tracer. Span contexts travel in the
+B3 multi header form.
+*/
+package opentracing
+
+import (
+	"strings"
+
+	ot "github.com/opentracing/opentracing-go"
+	"github.com/ximply/traceid"
+	"github.com/ximply/traceid/propagation"
+)
+
+// SpanContext is the opentracing.SpanContext of this package. It carries no
+// baggage.
+type SpanContext struct {
+	TraceID traceid.TraceID
+	SpanID  traceid.SpanID
+	Sampled bool
+}
+
+// ForeachBaggageItem implements opentracing.SpanContext.
+func (SpanContext) ForeachBaggageItem(handler func(k, v string) bool) {}
+
+// Inject writes ctx, which must be a SpanContext, to carrier. format must be
+// opentracing.TextMap or opentracing.HTTPHeaders and carrier an
+// opentracing.TextMapWriter, such as TextMapCarrier and HTTPHeadersCarrier.
+func Inject(ctx ot.SpanContext, format interface{}, carrier interface{}) error {
+	sc, ok := ctx.(SpanContext)
+	if !ok {
+		return ot.ErrInvalidSpanContext
+	}
+	if !isTextFormat(format) {
+		return ot.ErrUnsupportedFormat
+	}
+	w, ok := carrier.(ot.TextMapWriter)
+	if !ok {
+		return ot.ErrInvalidCarrier
+	}
+	return propagation.B3Propagator{}.InjectSpan(sc.TraceID, sc.SpanID, sc.Sampled, writer{w})
+}
+
+// Extract reads the trace id from carrier, an opentracing.TextMapReader in
+// format opentracing.TextMap or opentracing.HTTPHeaders. Header names are
+// matched case insensitively. Without a trace id it returns
+// opentracing.ErrSpanContextNotFound.
+func Extract(format interface{}, carrier interface{}) (traceid.TraceID, error) {
+	if !isTextFormat(format) {
+		return traceid.TraceID{}, ot.ErrUnsupportedFormat
+	}
+	r, ok := carrier.(ot.TextMapReader)
+	if !ok {
+		return traceid.TraceID{}, ot.ErrInvalidCarrier
+	}
+	m := make(map[string]string)
+	err := r.ForeachKey(func(key, val string) error {
+		m[strings.ToLower(key)] = val
+		return nil
+	})
+	if err != nil {
+		return traceid.TraceID{}, err
+	}
+	id, err := propagation.B3Propagator{}.Extract(propagation.MapCarrier(m))
+	if err != nil && propagation.ErrNoB3.Equal(err) {
+		return traceid.TraceID{}, ot.ErrSpanContextNotFound
+	}
+	return id, err
+}
+
+func isTextFormat(format interface{}) bool {
+	return format == ot.TextMap || format == ot.HTTPHeaders
+}
+
+// writer adapts an opentracing.TextMapWriter to propagation.Carrier for
+// writing.
+type writer struct {
+	ot.TextMapWriter
+}
+
+func (writer) Get(key string) string {
+	return ""
+}
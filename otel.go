@@ -0,0 +1,25 @@
+package traceid
+
+// OpenTelemetry represents trace ids as [16]byte holding the 128 bit value
+// big-endian: bytes 0-7 are High and bytes 8-15 are Low. A 64 bit TraceID
+// therefore has its value in the last 8 bytes.
+
+// FromOtelTraceID returns the TraceID of an OpenTelemetry trace.TraceID.
+func FromOtelTraceID(id [16]byte) TraceID {
+	return TraceIDFromBytes(id)
+}
+
+// ToOtelBytes returns the TraceID as an OpenTelemetry trace.TraceID.
+func (t TraceID) ToOtelBytes() [16]byte {
+	return t.Bytes()
+}
+
+// FromOTel is an alias for FromOtelTraceID.
+func FromOTel(id [16]byte) TraceID {
+	return FromOtelTraceID(id)
+}
+
+// OTelBytes is an alias for ToOtelBytes.
+func (t TraceID) OTelBytes() [16]byte {
+	return t.ToOtelBytes()
+}
@@ -0,0 +1,37 @@
+package traceid
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+)
+
+func TestOTelBytes(t *testing.T) {
+	for _, tt := range []struct {
+		id  TraceID
+		hex string
+	}{
+		{TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}, "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{TraceID{Low: 0xab}, "000000000000000000000000000000ab"},
+		{TraceID{High: 1}, "00000000000000010000000000000000"},
+		{TraceID{}, "00000000000000000000000000000000"},
+	} {
+		b := tt.id.OTelBytes()
+		// OpenTelemetry renders trace.TraceID as the hex of its bytes
+		if got := hex.EncodeToString(b[:]); got != tt.hex {
+			t.Errorf("hex of %v.OTelBytes() = %s, want %s", tt.id, got, tt.hex)
+		}
+		if got := fmt.Sprintf("%x", tt.id); got != tt.hex {
+			t.Errorf("%%x of %v = %s, want the OpenTelemetry hex %s", tt.id, got, tt.hex)
+		}
+		if b != tt.id.ToOtelBytes() {
+			t.Errorf("OTelBytes and ToOtelBytes of %v differ", tt.id)
+		}
+		if got := FromOTel(b); got != tt.id {
+			t.Errorf("FromOTel(%x) = %v, want %v", b, got, tt.id)
+		}
+		if got := FromOtelTraceID(b); got != tt.id {
+			t.Errorf("FromOtelTraceID(%x) = %v, want %v", b, got, tt.id)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package traceid
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Parse returns the TraceID from any of the formats trace ids travel in,
+// detected in this order:
+//
+//  1. 36 characters containing a dash: a UUID, see ParseUUID
+//  2. 16 or 32 characters: hex, see TraceIDFromHex
+//  3. decimal digits only, fitting in a uint64: stored in Low, the Zipkin v1
+//     style
+//  4. anything else, including longer decimals: hex of 1 to 32 characters,
+//     see ParseHex
+//
+// So a string such as "1234567890123456", valid both as hex and as decimal,
+// is read as hex because of its length, while "12345" is decimal.
+func Parse(s string) (TraceID, error) {
+	switch {
+	case len(s) == 36 && strings.Contains(s, "-"):
+		return ParseUUID(s)
+	case len(s) == 16 || len(s) == 32:
+		return TraceIDFromHex(s)
+	case isDecimal(s):
+		if low, err := strconv.ParseUint(s, 10, 64); err == nil {
+			return TraceID{Low: low}, nil
+		}
+	}
+	return ParseHex(s)
+}
+
+// MustParse is like Parse but panics on bad input, for constants in tests
+// and wiring code.
+func MustParse(s string) TraceID {
+	t, err := Parse(s)
+	if err != nil {
+		panic("traceid: MustParse(" + strconv.Quote(s) + "): " + err.Error())
+	}
+	return t
+}
+
+func isDecimal(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
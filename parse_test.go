@@ -0,0 +1,48 @@
+package traceid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	w3c := TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	tests := []struct {
+		in   string
+		want TraceID
+	}{
+		{"4bf92f3577b34da6a3ce929d0e0e4736", w3c},
+		{"4bf92f35-77b3-4da6-a3ce-929d0e0e4736", w3c},
+		{"a3ce929d0e0e4736", TraceID{Low: 0xa3ce929d0e0e4736}},
+		{"1234567890123456", TraceID{Low: 0x1234567890123456}},
+		{"12345", TraceID{Low: 12345}},
+		{"18446744073709551615", TraceID{Low: ^uint64(0)}},
+		{"18446744073709551616", TraceID{High: 0x1844, Low: 0x6744073709551616}},
+		{"99999999999999999999", TraceID{High: 0x9999, Low: 0x9999999999999999}},
+		{"abc", TraceID{Low: 0xabc}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %#v, %v, want %#v", tt.in, got, err, tt.want)
+		}
+		if got := MustParse(tt.in); got != tt.want {
+			t.Errorf("MustParse(%q) = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+	for _, in := range []string{"", "xyz", "4bf92f35-77b3-4da6-a3ce-929d0e0e473x", "4bf92f3577b34da6a3ce929d0e0e47360"} {
+		if got, err := Parse(in); err == nil {
+			t.Errorf("Parse(%q) = %v, want an error", in, got)
+		}
+	}
+}
+
+func TestMustParsePanics(t *testing.T) {
+	defer func() {
+		r := recover()
+		if s, ok := r.(string); !ok || !strings.Contains(s, `MustParse("xyz")`) {
+			t.Errorf("recovered %v, want a panic naming the input", r)
+		}
+	}()
+	MustParse("xyz")
+}
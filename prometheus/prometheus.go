@@ -0,0 +1,58 @@
+/*
+Package prometheus instruments ID Generators with Prometheus metrics, to tell
+whether id generation, for instance a contended lock, adds to request
+latency.
+*/
+package prometheus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/ximply/traceid"
+	"github.com/ximply/traceid/idgenerator"
+)
+
+// newDurations returns the histogram all instrumented generators record to,
+// labeled with the type of the wrapped generator.
+func newDurations() *prometheus.HistogramVec {
+	return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "traceid",
+		Name:      "generation_duration_seconds",
+		Help:      "Time taken to generate a trace id.",
+		Buckets:   prometheus.ExponentialBuckets(100e-9, 4, 8), // 100ns to 1.6ms
+	}, []string{"generator"})
+}
+
+// InstrumentedGenerator returns an ID Generator which records the duration of
+// every call to base in the histogram traceid_generation_duration_seconds
+// registered with reg, labeled generator with the Go type of base. Several
+// instrumented generators share the histogram. It panics if reg holds an
+// incompatible collector of that name, like prometheus.MustRegister.
+func InstrumentedGenerator(base idgenerator.IDGenerator, reg prometheus.Registerer) idgenerator.IDGenerator {
+	durations := newDurations()
+	if err := reg.Register(durations); err != nil {
+		are, ok := err.(prometheus.AlreadyRegisteredError)
+		if !ok {
+			panic(err)
+		}
+		durations = are.ExistingCollector.(*prometheus.HistogramVec)
+	}
+	return &instrumented{
+		base:     base,
+		observer: durations.WithLabelValues(fmt.Sprintf("%T", base)),
+	}
+}
+
+type instrumented struct {
+	base     idgenerator.IDGenerator
+	observer prometheus.Observer
+}
+
+func (i *instrumented) TraceID() traceid.TraceID {
+	start := time.Now()
+	id := i.base.TraceID()
+	i.observer.Observe(time.Since(start).Seconds())
+	return id
+}
@@ -0,0 +1,236 @@
+package propagation
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/kataras/iris/core/errors"
+	"github.com/ximply/traceid"
+)
+
+// B3 headers
+const (
+	B3TraceIDHeader      = "X-B3-TraceId"
+	B3SpanIDHeader       = "X-B3-SpanId"
+	B3ParentSpanIDHeader = "X-B3-ParentSpanId"
+	B3SampledHeader      = "X-B3-Sampled"
+	B3FlagsHeader        = "X-B3-Flags"
+	B3SingleHeader       = "b3"
+)
+
+// B3 errors
+var (
+	ErrNoB3            = errors.New("b3 headers not found")
+	ErrInvalidB3       = errors.New("invalid b3 header %q")
+	ErrInvalidB3Sample = errors.New("invalid b3 sampling state %q")
+	ErrInvalidB3Field  = errors.New("invalid %s in b3 header: %q")
+)
+
+// B3Propagator injects and extracts Zipkin's B3 headers, either the
+// X-B3-* multi header form or, with SingleHeader set, the single b3 header.
+type B3Propagator struct {
+	SingleHeader bool
+}
+
+// Inject writes id to the B3 headers of carrier with a random span id,
+// leaving the sampling decision open.
+func (p B3Propagator) Inject(id traceid.TraceID, carrier Carrier) error {
+	return p.InjectContext(context.Background(), id, carrier)
+}
+
+// InjectContext writes id to the B3 headers of carrier with the span id and
+// sampling decision of ctx, see ContextInjector. An open decision leaves out
+// X-B3-Sampled and the sampling state of the single header.
+func (p B3Propagator) InjectContext(ctx context.Context, id traceid.TraceID, carrier Carrier) error {
+	if id.IsZero() {
+		return ErrZeroID
+	}
+	spanID, sampled := spanFromContext(ctx)
+	if p.SingleHeader {
+		carrier.Set(B3SingleHeader, FormatB3Single(id, spanID, b3Sampling(sampled), 0))
+		return nil
+	}
+	injectB3(carrier, id, spanID, sampled)
+	return nil
+}
+
+// Extract reads the trace id from the B3 headers of carrier. A bare sampling
+// decision carries no trace id and yields ErrNoB3.
+func (p B3Propagator) Extract(carrier Carrier) (traceid.TraceID, error) {
+	id, _, _, err := p.ExtractSpan(carrier)
+	if err == nil && id.IsZero() {
+		err = ErrNoB3
+	}
+	return id, err
+}
+
+// InjectSpan writes the B3 headers for the given ids to carrier.
+func (p B3Propagator) InjectSpan(id traceid.TraceID, spanID traceid.SpanID, sampled bool, carrier Carrier) error {
+	if id.IsZero() || spanID.IsZero() {
+		return ErrZeroID
+	}
+	if p.SingleHeader {
+		carrier.Set(B3SingleHeader, FormatB3Single(id, spanID, b3Sampling(&sampled), 0))
+		return nil
+	}
+	injectB3(carrier, id, spanID, &sampled)
+	return nil
+}
+
+// ExtractSpan reads the B3 headers from carrier. A sampling decision without
+// ids, such as the single header shorthands "b3: 0" and "b3: 1", is returned
+// with a zero TraceID and SpanID.
+func (p B3Propagator) ExtractSpan(carrier Carrier) (id traceid.TraceID, spanID traceid.SpanID, sampled bool, err error) {
+	if p.SingleHeader {
+		return extractB3Single(carrier.Get(B3SingleHeader))
+	}
+	var state *bool
+	id, spanID, state, err = extractB3(carrier)
+	sampled = state != nil && *state
+	return
+}
+
+// ExtractB3 reads the X-B3-* headers from h. sampled is nil if the caller
+// left the sampling decision open and true for the debug flag. A sampling
+// decision without ids is returned with a zero TraceID and SpanID.
+func ExtractB3(h http.Header) (id traceid.TraceID, spanID traceid.SpanID, sampled *bool, err error) {
+	return extractB3(h)
+}
+
+// InjectB3 writes the X-B3-* headers for the given ids to h, omitting
+// X-B3-Sampled if sampled is nil. With a zero id only the sampling decision is
+// written. Trace ids with zero High are written as 16 characters.
+func InjectB3(h http.Header, id traceid.TraceID, spanID traceid.SpanID, sampled *bool) {
+	injectB3(h, id, spanID, sampled)
+}
+
+func extractB3(carrier Carrier) (id traceid.TraceID, spanID traceid.SpanID, sampled *bool, err error) {
+	h, s, f := carrier.Get(B3TraceIDHeader), carrier.Get(B3SampledHeader), carrier.Get(B3FlagsHeader)
+	if h == "" && s == "" && f == "" {
+		err = ErrNoB3
+		return
+	}
+	if f == "1" {
+		debug := true
+		sampled = &debug
+	} else if s != "" {
+		var state bool
+		if state, err = parseB3Sampled(s); err != nil {
+			return
+		}
+		sampled = &state
+	}
+	if h == "" {
+		return
+	}
+	if id, err = traceid.TraceIDFromHex(h); err != nil {
+		return
+	}
+	err = spanID.UnmarshalText([]byte(carrier.Get(B3SpanIDHeader)))
+	return
+}
+
+func injectB3(carrier Carrier, id traceid.TraceID, spanID traceid.SpanID, sampled *bool) {
+	if !id.IsZero() {
+		carrier.Set(B3TraceIDHeader, id.String())
+		carrier.Set(B3SpanIDHeader, spanID.String())
+	}
+	if sampled != nil {
+		carrier.Set(B3SampledHeader, b3Sampling(sampled))
+	}
+}
+
+// b3Sampling returns the B3 sampling state of sampled, "" if it is nil.
+func b3Sampling(sampled *bool) string {
+	switch {
+	case sampled == nil:
+		return ""
+	case *sampled:
+		return "1"
+	}
+	return "0"
+}
+
+func extractB3Single(h string) (id traceid.TraceID, spanID traceid.SpanID, sampled bool, err error) {
+	if h == "" {
+		err = ErrNoB3
+		return
+	}
+	var state string
+	id, spanID, state, _, err = ParseB3Single(h)
+	sampled = state == "1" || state == "d"
+	return
+}
+
+// FormatB3Single returns the single b3 header value
+// {trace id}-{span id}-{sampling state}-{parent span id}. sampling is "" to
+// leave the decision open or one of "0", "1" and "d" (debug), a zero parentID
+// is left out. With a zero id only the sampling state is returned.
+func FormatB3Single(id traceid.TraceID, spanID traceid.SpanID, sampling string, parentID traceid.SpanID) string {
+	if id.IsZero() {
+		return sampling
+	}
+	h := id.String() + "-" + spanID.String()
+	if sampling != "" {
+		h += "-" + sampling
+	}
+	if !parentID.IsZero() {
+		h += "-" + parentID.String()
+	}
+	return h
+}
+
+// ParseB3Single parses a single b3 header value, see FormatB3Single. A bare
+// sampling state such as "0" carries no ids and yields a zero TraceID and
+// SpanID. Errors name the malformed field.
+func ParseB3Single(h string) (id traceid.TraceID, spanID traceid.SpanID, sampling string, parentID traceid.SpanID, err error) {
+	parts := strings.Split(h, "-")
+	if len(parts) == 1 {
+		if !isB3SingleSampling(h) {
+			err = ErrInvalidB3Field.Format("sampling state", h)
+			return
+		}
+		sampling = h
+		return
+	}
+	if len(parts) > 4 {
+		err = ErrInvalidB3.Format(h)
+		return
+	}
+	if id, err = traceid.TraceIDFromHex(parts[0]); err != nil {
+		err = ErrInvalidB3Field.Format("trace id", parts[0])
+		return
+	}
+	if err = spanID.UnmarshalText([]byte(parts[1])); err != nil {
+		err = ErrInvalidB3Field.Format("span id", parts[1])
+		return
+	}
+	if len(parts) > 2 {
+		if sampling = parts[2]; !isB3SingleSampling(sampling) {
+			err = ErrInvalidB3Field.Format("sampling state", sampling)
+			return
+		}
+	}
+	if len(parts) > 3 {
+		if err = parentID.UnmarshalText([]byte(parts[3])); err != nil {
+			err = ErrInvalidB3Field.Format("parent span id", parts[3])
+		}
+	}
+	return
+}
+
+func isB3SingleSampling(s string) bool {
+	return s == "0" || s == "1" || s == "d"
+}
+
+// parseB3Sampled parses a B3 sampling state, treating debug as sampled.
+func parseB3Sampled(s string) (bool, error) {
+	switch s {
+	case "1", "true", "d":
+		return true, nil
+	case "0", "false":
+		return false, nil
+	}
+	return false, ErrInvalidB3Sample.Format(s)
+}
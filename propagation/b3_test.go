@@ -0,0 +1,234 @@
+package propagation
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ximply/traceid"
+)
+
+func TestExtractB3(t *testing.T) {
+	yes, no := true, false
+	tests := []struct {
+		header  map[string]string
+		id      traceid.TraceID
+		span    traceid.SpanID
+		sampled *bool
+	}{
+		{
+			header:  map[string]string{"X-B3-TraceId": "4bf92f3577b34da6a3ce929d0e0e4736", "X-B3-SpanId": "00f067aa0ba902b7", "X-B3-Sampled": "1"},
+			id:      traceid.TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736},
+			span:    0x00f067aa0ba902b7,
+			sampled: &yes,
+		},
+		{
+			header:  map[string]string{"x-b3-traceid": "A3CE929D0E0E4736", "x-b3-spanid": "00F067aa0BA902b7", "x-b3-sampled": "0"},
+			id:      traceid.TraceID{Low: 0xa3ce929d0e0e4736},
+			span:    0x00f067aa0ba902b7,
+			sampled: &no,
+		},
+		{
+			header: map[string]string{"X-B3-TraceId": "a3ce929d0e0e4736", "X-B3-SpanId": "00f067aa0ba902b7"},
+			id:     traceid.TraceID{Low: 0xa3ce929d0e0e4736},
+			span:   0x00f067aa0ba902b7,
+		},
+		{
+			header:  map[string]string{"X-B3-TraceId": "a3ce929d0e0e4736", "X-B3-SpanId": "00f067aa0ba902b7", "X-B3-Sampled": "0", "X-B3-Flags": "1"},
+			id:      traceid.TraceID{Low: 0xa3ce929d0e0e4736},
+			span:    0x00f067aa0ba902b7,
+			sampled: &yes,
+		},
+		{
+			header:  map[string]string{"X-B3-Sampled": "true"},
+			sampled: &yes,
+		},
+		{
+			header:  map[string]string{"X-B3-Flags": "1"},
+			sampled: &yes,
+		},
+	}
+	for _, tt := range tests {
+		h := http.Header{}
+		for k, v := range tt.header {
+			h.Set(k, v)
+		}
+		id, span, sampled, err := ExtractB3(h)
+		if err != nil {
+			t.Errorf("ExtractB3(%v): %v", tt.header, err)
+			continue
+		}
+		if id != tt.id || span != tt.span {
+			t.Errorf("ExtractB3(%v) = %v, %v, want %v, %v", tt.header, id, span, tt.id, tt.span)
+		}
+		if (sampled == nil) != (tt.sampled == nil) || sampled != nil && *sampled != *tt.sampled {
+			t.Errorf("ExtractB3(%v) sampled = %v, want %v", tt.header, sampled, tt.sampled)
+		}
+	}
+}
+
+func TestExtractB3Invalid(t *testing.T) {
+	for _, header := range []map[string]string{
+		{"X-B3-TraceId": "4bf92f3577b34da6a3ce929d0e0e473", "X-B3-SpanId": "00f067aa0ba902b7"},
+		{"X-B3-TraceId": "zzf92f3577b34da6", "X-B3-SpanId": "00f067aa0ba902b7"},
+		{"X-B3-TraceId": "4bf92f3577b34da6", "X-B3-SpanId": "f067aa0ba902b7"},
+		{"X-B3-TraceId": "4bf92f3577b34da6", "X-B3-SpanId": "00f067aa0ba902bx"},
+		{"X-B3-TraceId": "4bf92f3577b34da6"},
+		{"X-B3-TraceId": "4bf92f3577b34da6", "X-B3-SpanId": "00f067aa0ba902b7", "X-B3-Sampled": "yes"},
+		{"X-B3-Sampled": "2"},
+	} {
+		h := http.Header{}
+		for k, v := range header {
+			h.Set(k, v)
+		}
+		if _, _, _, err := ExtractB3(h); err == nil {
+			t.Errorf("ExtractB3(%v) succeeded, want an error", header)
+		}
+	}
+	if _, _, _, err := ExtractB3(http.Header{}); err == nil || !ErrNoB3.Equal(err) {
+		t.Errorf("ExtractB3 of empty header error = %v, want ErrNoB3", err)
+	}
+}
+
+func TestInjectB3(t *testing.T) {
+	yes := true
+	h := http.Header{}
+	InjectB3(h, traceid.TraceID{Low: 0xa3ce929d0e0e4736}, 0x00f067aa0ba902b7, &yes)
+	if got, want := h.Get(B3TraceIDHeader), "a3ce929d0e0e4736"; got != want {
+		t.Errorf("%s = %q, want %q", B3TraceIDHeader, got, want)
+	}
+	if got, want := h.Get(B3SpanIDHeader), "00f067aa0ba902b7"; got != want {
+		t.Errorf("%s = %q, want %q", B3SpanIDHeader, got, want)
+	}
+	if got, want := h.Get(B3SampledHeader), "1"; got != want {
+		t.Errorf("%s = %q, want %q", B3SampledHeader, got, want)
+	}
+
+	h = http.Header{}
+	id := traceid.TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	InjectB3(h, id, 0x00f067aa0ba902b7, nil)
+	if got, want := h.Get(B3TraceIDHeader), "4bf92f3577b34da6a3ce929d0e0e4736"; got != want {
+		t.Errorf("%s = %q, want %q", B3TraceIDHeader, got, want)
+	}
+	if _, ok := h[http.CanonicalHeaderKey(B3SampledHeader)]; ok {
+		t.Errorf("%s written for an open sampling decision", B3SampledHeader)
+	}
+	gotID, gotSpan, sampled, err := ExtractB3(h)
+	if err != nil || gotID != id || gotSpan != 0x00f067aa0ba902b7 || sampled != nil {
+		t.Errorf("ExtractB3 = %v, %v, %v, %v", gotID, gotSpan, sampled, err)
+	}
+
+	h = http.Header{}
+	no := false
+	InjectB3(h, traceid.TraceID{}, 0, &no)
+	if len(h) != 1 || h.Get(B3SampledHeader) != "0" {
+		t.Errorf("InjectB3 of a bare sampling decision = %v", h)
+	}
+}
+
+func TestB3Propagator(t *testing.T) {
+	id := traceid.TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	for _, p := range []B3Propagator{{}, {SingleHeader: true}} {
+		h := http.Header{}
+		if err := p.Inject(id, h); err != nil {
+			t.Fatal(err)
+		}
+		if got, err := p.Extract(h); err != nil || got != id {
+			t.Errorf("%+v: Extract = %v, %v, want %v", p, got, err, id)
+		}
+		if err := p.Inject(traceid.TraceID{}, h); err == nil || !ErrZeroID.Equal(err) {
+			t.Errorf("%+v: Inject of zero id error = %v, want ErrZeroID", p, err)
+		}
+	}
+	h := http.Header{}
+	h.Set(B3SampledHeader, "1")
+	if _, err := (B3Propagator{}).Extract(h); err == nil || !ErrNoB3.Equal(err) {
+		t.Errorf("Extract of a bare sampling decision error = %v, want ErrNoB3", err)
+	}
+}
+
+func TestParseB3Single(t *testing.T) {
+	specID := traceid.TraceID{High: 0x80f198ee56343ba8, Low: 0x64fe8b2a57d3eff7}
+	tests := []struct {
+		in       string
+		id       traceid.TraceID
+		span     traceid.SpanID
+		sampling string
+		parent   traceid.SpanID
+	}{
+		{"80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1-05e3ac9a4f6e3b90", specID, 0xe457b5a2e4d86bd1, "1", 0x05e3ac9a4f6e3b90},
+		{"80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1", specID, 0xe457b5a2e4d86bd1, "1", 0},
+		{"80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-d", specID, 0xe457b5a2e4d86bd1, "d", 0},
+		{"80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1", specID, 0xe457b5a2e4d86bd1, "", 0},
+		{"64fe8b2a57d3eff7-e457b5a2e4d86bd1-0", traceid.TraceID{Low: 0x64fe8b2a57d3eff7}, 0xe457b5a2e4d86bd1, "0", 0},
+		{"0", traceid.TraceID{}, 0, "0", 0},
+		{"1", traceid.TraceID{}, 0, "1", 0},
+		{"d", traceid.TraceID{}, 0, "d", 0},
+	}
+	for _, tt := range tests {
+		id, span, sampling, parent, err := ParseB3Single(tt.in)
+		if err != nil {
+			t.Errorf("ParseB3Single(%q): %v", tt.in, err)
+			continue
+		}
+		if id != tt.id || span != tt.span || sampling != tt.sampling || parent != tt.parent {
+			t.Errorf("ParseB3Single(%q) = %v, %v, %q, %v", tt.in, id, span, sampling, parent)
+		}
+		if got := FormatB3Single(id, span, sampling, parent); got != tt.in {
+			t.Errorf("FormatB3Single(ParseB3Single(%q)) = %q", tt.in, got)
+		}
+	}
+}
+
+func TestParseB3SingleInvalid(t *testing.T) {
+	tests := []struct {
+		in    string
+		field string
+	}{
+		{"2", "sampling state"},
+		{"true", "sampling state"},
+		{"80f198ee56343ba864fe8b2a57d3eff-e457b5a2e4d86bd1-1", "trace id"},
+		{"zzf198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1", "trace id"},
+		{"80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd-1", "span id"},
+		{"80f198ee56343ba864fe8b2a57d3eff7--1", "span id"},
+		{"80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-x", "sampling state"},
+		{"80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1-05e3ac9a4f6e3b9", "parent span id"},
+	}
+	for _, tt := range tests {
+		_, _, _, _, err := ParseB3Single(tt.in)
+		if err == nil || !ErrInvalidB3Field.Equal(err) {
+			t.Errorf("ParseB3Single(%q) error = %v, want ErrInvalidB3Field", tt.in, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.field) {
+			t.Errorf("ParseB3Single(%q) error = %q, want it to name the %s", tt.in, err, tt.field)
+		}
+	}
+	in := "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1-05e3ac9a4f6e3b90-1"
+	if _, _, _, _, err := ParseB3Single(in); err == nil || !ErrInvalidB3.Equal(err) {
+		t.Errorf("ParseB3Single(%q) error = %v, want ErrInvalidB3", in, err)
+	}
+}
+
+func TestB3PropagatorSingleSampling(t *testing.T) {
+	p := B3Propagator{SingleHeader: true}
+	for in, want := range map[string]bool{"0": false, "1": true, "d": true} {
+		h := http.Header{}
+		h.Set(B3SingleHeader, in)
+		id, span, sampled, err := p.ExtractSpan(h)
+		if err != nil || !id.IsZero() || !span.IsZero() || sampled != want {
+			t.Errorf("ExtractSpan(b3: %s) = %v, %v, %v, %v", in, id, span, sampled, err)
+		}
+		if _, err := p.Extract(h); err == nil || !ErrNoB3.Equal(err) {
+			t.Errorf("Extract(b3: %s) error = %v, want ErrNoB3", in, err)
+		}
+	}
+	id := traceid.TraceID{Low: 0x64fe8b2a57d3eff7}
+	h := http.Header{}
+	if err := p.InjectSpan(id, 0xe457b5a2e4d86bd1, false, h); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := h.Get(B3SingleHeader), "64fe8b2a57d3eff7-e457b5a2e4d86bd1-0"; got != want {
+		t.Errorf("b3 = %q, want %q", got, want)
+	}
+}
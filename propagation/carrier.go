@@ -0,0 +1,91 @@
+package propagation
+
+import (
+	"net/http"
+	"strings"
+)
+
+// TextCarrier is a Carrier which can list its keys, as message headers of
+// Kafka or AMQP can.
+type TextCarrier interface {
+	Carrier
+	Keys() []string
+}
+
+// HTTPCarrier returns a Carrier reading and writing h.
+func HTTPCarrier(h http.Header) TextCarrier {
+	return httpCarrier(h)
+}
+
+// httpCarrier delegates to the canonicalizing methods of http.Header.
+type httpCarrier http.Header
+
+func (c httpCarrier) Set(key, value string) {
+	http.Header(c).Set(key, value)
+}
+
+func (c httpCarrier) Get(key string) string {
+	return http.Header(c).Get(key)
+}
+
+func (c httpCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// MapCarrier returns a Carrier reading and writing m. Keys are lowercased,
+// which matches the conventions of gRPC metadata.
+func MapCarrier(m map[string]string) TextCarrier {
+	return mapCarrier(m)
+}
+
+// mapCarrier stores values under lowercased keys.
+type mapCarrier map[string]string
+
+func (c mapCarrier) Set(key, value string) {
+	c[strings.ToLower(key)] = value
+}
+
+func (c mapCarrier) Get(key string) string {
+	return c[strings.ToLower(key)]
+}
+
+func (c mapCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// MultiMapCarrier returns a Carrier reading and writing m, such as gRPC's
+// metadata.MD, without importing gRPC. Keys are lowercased, Set replaces all
+// values of a key and Get returns the first.
+func MultiMapCarrier(m map[string][]string) TextCarrier {
+	return multiMapCarrier(m)
+}
+
+// multiMapCarrier stores values under lowercased keys.
+type multiMapCarrier map[string][]string
+
+func (c multiMapCarrier) Set(key, value string) {
+	c[strings.ToLower(key)] = []string{value}
+}
+
+func (c multiMapCarrier) Get(key string) string {
+	if v := c[strings.ToLower(key)]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (c multiMapCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -0,0 +1,74 @@
+package propagation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ximply/traceid"
+)
+
+func TestInjectContext(t *testing.T) {
+	id := traceid.TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	span := traceid.NewSpanContext(context.Background(), 0x00f067aa0ba902b7)
+	tests := []struct {
+		p      Propagator
+		ctx    context.Context
+		header string
+		want   string
+	}{
+		{B3Propagator{}, traceid.NewSampledContext(span, true), B3SampledHeader, "1"},
+		{B3Propagator{}, traceid.NewSampledContext(span, false), B3SampledHeader, "0"},
+		{B3Propagator{}, span, B3SampledHeader, ""},
+		{B3Propagator{}, span, B3SpanIDHeader, "00f067aa0ba902b7"},
+		{B3Propagator{SingleHeader: true}, traceid.NewSampledContext(span, true), B3SingleHeader, "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1"},
+		{B3Propagator{SingleHeader: true}, traceid.NewSampledContext(span, false), B3SingleHeader, "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0"},
+		{B3Propagator{SingleHeader: true}, span, B3SingleHeader, "4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7"},
+		{W3CPropagator{}, traceid.NewSampledContext(span, false), TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"},
+		{W3CPropagator{}, span, TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"},
+		{JaegerPropagator{}, traceid.NewSampledContext(span, false), JaegerHeader, "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0000000000000000:0"},
+		{JaegerPropagator{}, span, JaegerHeader, "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0000000000000000:0"},
+		{DatadogPropagator{}, traceid.NewSampledContext(span, true), DatadogSamplingHeader, "1"},
+		{DatadogPropagator{}, traceid.NewSampledContext(span, false), DatadogSamplingHeader, "0"},
+		{DatadogPropagator{}, span, DatadogSamplingHeader, ""},
+		{DatadogPropagator{}, span, DatadogParentIDHeader, "67667974448284343"},
+		{HeaderPropagator{Header: RequestIDHeader}, span, RequestIDHeader, "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{NewMultiPropagator(B3Propagator{}, W3CPropagator{}), traceid.NewSampledContext(span, true), TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+	}
+	for _, tt := range tests {
+		h := http.Header{}
+		if err := InjectContext(tt.ctx, tt.p, id, h); err != nil {
+			t.Errorf("%T: InjectContext: %v", tt.p, err)
+			continue
+		}
+		if got := h.Get(tt.header); got != tt.want {
+			t.Errorf("%T: %s = %q, want %q", tt.p, tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestInjectRandomSpan(t *testing.T) {
+	id := traceid.TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	spans := make(map[traceid.SpanID]bool)
+	for i := 0; i < 4; i++ {
+		h := http.Header{}
+		if err := (B3Propagator{}).Inject(id, h); err != nil {
+			t.Fatal(err)
+		}
+		_, span, sampled, err := extractB3(h)
+		if err != nil || span.IsZero() || uint64(span) == id.Low || sampled != nil {
+			t.Errorf("Inject sent span id %v, sampled %v, %v, want a random span id and no decision", span, sampled, err)
+		}
+		spans[span] = true
+	}
+	if len(spans) < 2 {
+		t.Errorf("Inject sent the same span id every time: %v", spans)
+	}
+	h := http.Header{}
+	if err := (W3CPropagator{}).Inject(id, h); err != nil {
+		t.Fatal(err)
+	}
+	if _, span, flags, err := ParseTraceparent(h.Get(TraceparentHeader)); err != nil || uint64(span) == id.Low || flags != 0 {
+		t.Errorf("traceparent = %q, want a random parent id and flags 00", h.Get(TraceparentHeader))
+	}
+}
@@ -0,0 +1,114 @@
+package propagation
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/kataras/iris/core/errors"
+	"github.com/ximply/traceid"
+)
+
+// Datadog headers
+const (
+	DatadogTraceIDHeader  = "x-datadog-trace-id"
+	DatadogParentIDHeader = "x-datadog-parent-id"
+	DatadogTagsHeader     = "x-datadog-tags"
+	DatadogSamplingHeader = "x-datadog-sampling-priority"
+)
+
+// Datadog errors
+var (
+	ErrNoDatadog      = errors.New("x-datadog-trace-id header not found")
+	ErrInvalidDatadog = errors.New("invalid x-datadog-trace-id header %q")
+)
+
+// DatadogPropagator injects and extracts the Datadog x-datadog-* headers,
+// which carry 64 bit ids as unsigned decimal numbers. High travels as the
+// _dd.p.tid tag of the x-datadog-tags header.
+type DatadogPropagator struct{}
+
+// Inject writes the low 64 bits of id to carrier with a random parent id and
+// no sampling priority, and High as _dd.p.tid tag unless it is zero. Other
+// tags already in x-datadog-tags are kept, a _dd.p.tid tag there is replaced.
+func (p DatadogPropagator) Inject(id traceid.TraceID, carrier Carrier) error {
+	return p.InjectContext(context.Background(), id, carrier)
+}
+
+// InjectContext is like Inject but sends the span id of ctx as parent id and
+// its sampling decision as sampling priority 1 or 0, see ContextInjector.
+func (DatadogPropagator) InjectContext(ctx context.Context, id traceid.TraceID, carrier Carrier) error {
+	if id.Low == 0 {
+		return ErrZeroID
+	}
+	spanID, sampled := spanFromContext(ctx)
+	carrier.Set(DatadogTraceIDHeader, id.DatadogString())
+	carrier.Set(DatadogParentIDHeader, strconv.FormatUint(uint64(spanID), 10))
+	if sampled != nil {
+		priority := "0"
+		if *sampled {
+			priority = "1"
+		}
+		carrier.Set(DatadogSamplingHeader, priority)
+	}
+	old := carrier.Get(DatadogTagsHeader)
+	if tags := setDatadogTag(old, traceid.DatadogTIDTag, id.DatadogTID()); tags != old {
+		carrier.Set(DatadogTagsHeader, tags)
+	}
+	return nil
+}
+
+// Extract reads the trace id from carrier, taking High from the _dd.p.tid
+// tag if present. Values which are not decimal, overflow uint64 or are zero
+// are rejected. A malformed tag is ignored and the 64 bit id returned, as
+// Datadog's tracers do.
+func (DatadogPropagator) Extract(carrier Carrier) (traceid.TraceID, error) {
+	h := carrier.Get(DatadogTraceIDHeader)
+	if h == "" {
+		return traceid.TraceID{}, ErrNoDatadog
+	}
+	id, err := traceid.ParseDatadog(h)
+	if err != nil || id.Low == 0 {
+		return traceid.TraceID{}, ErrInvalidDatadog.Format(h)
+	}
+	if id128, err := traceid.ParseDatadog128(h, datadogTag(carrier.Get(DatadogTagsHeader), traceid.DatadogTIDTag)); err == nil {
+		id = id128
+	}
+	return id, nil
+}
+
+// datadogTag returns the value of key in the comma separated key=value list
+// of the x-datadog-tags header.
+func datadogTag(tags, key string) string {
+	for _, tag := range strings.Split(tags, ",") {
+		if kv := strings.SplitN(tag, "=", 2); len(kv) == 2 && strings.TrimSpace(kv[0]) == key {
+			return strings.TrimSpace(kv[1])
+		}
+	}
+	return ""
+}
+
+// setDatadogTag returns the x-datadog-tags value tags with key set to value,
+// replacing an existing entry in place or appending one. An empty value
+// removes key. Other entries are kept as they are.
+func setDatadogTag(tags, key, value string) string {
+	var out []string
+	found := false
+	for _, tag := range strings.Split(tags, ",") {
+		if strings.TrimSpace(tag) == "" {
+			continue
+		}
+		if kv := strings.SplitN(tag, "=", 2); strings.TrimSpace(kv[0]) == key {
+			if !found && value != "" {
+				out = append(out, key+"="+value)
+			}
+			found = true
+			continue
+		}
+		out = append(out, tag)
+	}
+	if !found && value != "" {
+		out = append(out, key+"="+value)
+	}
+	return strings.Join(out, ",")
+}
@@ -0,0 +1,88 @@
+package propagation
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ximply/traceid"
+)
+
+func TestDatadogExtract(t *testing.T) {
+	tests := []struct {
+		traceID, tags string
+		want          traceid.TraceID
+	}{
+		{"11803532876627986230", "", traceid.TraceID{Low: 0xa3ce929d0e0e4736}},
+		{"11803532876627986230", "_dd.p.dm=-0,_dd.p.tid=640cfd8d00000000", traceid.TraceID{High: 0x640cfd8d00000000, Low: 0xa3ce929d0e0e4736}},
+		{"11803532876627986230", "_dd.p.tid=640cfd8d", traceid.TraceID{Low: 0xa3ce929d0e0e4736}},
+		{"11803532876627986230", "_dd.p.tid=zzzzzzzzzzzzzzzz,_dd.p.dm=-1", traceid.TraceID{Low: 0xa3ce929d0e0e4736}},
+	}
+	for _, tt := range tests {
+		h := http.Header{}
+		h.Set(DatadogTraceIDHeader, tt.traceID)
+		h.Set(DatadogParentIDHeader, "5208512171318403364")
+		if tt.tags != "" {
+			h.Set(DatadogTagsHeader, tt.tags)
+		}
+		if got, err := (DatadogPropagator{}).Extract(h); err != nil || got != tt.want {
+			t.Errorf("Extract(%q, %q) = %v, %v, want %v", tt.traceID, tt.tags, got, err, tt.want)
+		}
+	}
+}
+
+func TestDatadogExtractInvalid(t *testing.T) {
+	for _, v := range []string{"0", "abc", "18446744073709551616"} {
+		h := http.Header{}
+		h.Set(DatadogTraceIDHeader, v)
+		if _, err := (DatadogPropagator{}).Extract(h); err == nil || !ErrInvalidDatadog.Equal(err) {
+			t.Errorf("Extract(%q) error = %v, want ErrInvalidDatadog", v, err)
+		}
+	}
+	if _, err := (DatadogPropagator{}).Extract(http.Header{}); err == nil || !ErrNoDatadog.Equal(err) {
+		t.Errorf("Extract of no headers error = %v, want ErrNoDatadog", err)
+	}
+}
+
+func TestDatadogInject(t *testing.T) {
+	h := http.Header{}
+	id := traceid.TraceID{High: 0x640cfd8d00000000, Low: 0xa3ce929d0e0e4736}
+	if err := (DatadogPropagator{}).Inject(id, h); err != nil {
+		t.Fatal(err)
+	}
+	if got := h.Get(DatadogTraceIDHeader); got != "11803532876627986230" {
+		t.Errorf("trace id header = %q", got)
+	}
+	if got := h.Get(DatadogTagsHeader); got != "_dd.p.tid=640cfd8d00000000" {
+		t.Errorf("tags header = %q", got)
+	}
+}
+
+func TestDatadogInjectKeepsTags(t *testing.T) {
+	tests := []struct {
+		id   traceid.TraceID
+		tags string
+		want string
+	}{
+		{traceid.TraceID{High: 0x640cfd8d00000000, Low: 1}, "_dd.p.dm=-4", "_dd.p.dm=-4,_dd.p.tid=640cfd8d00000000"},
+		{traceid.TraceID{High: 0x640cfd8d00000000, Low: 1}, "_dd.p.tid=1111111100000000,_dd.p.dm=-4", "_dd.p.tid=640cfd8d00000000,_dd.p.dm=-4"},
+		{traceid.TraceID{High: 0x640cfd8d00000000, Low: 1}, "_dd.p.dm=-4, _dd.p.tid=11", "_dd.p.dm=-4,_dd.p.tid=640cfd8d00000000"},
+		{traceid.TraceID{Low: 1}, "_dd.p.dm=-4,_dd.p.tid=640cfd8d00000000", "_dd.p.dm=-4"},
+		{traceid.TraceID{Low: 1}, "_dd.p.dm=-4", "_dd.p.dm=-4"},
+		{traceid.TraceID{Low: 1}, "", ""},
+	}
+	for _, tt := range tests {
+		h := http.Header{}
+		if tt.tags != "" {
+			h.Set(DatadogTagsHeader, tt.tags)
+		}
+		if err := (DatadogPropagator{}).Inject(tt.id, h); err != nil {
+			t.Fatal(err)
+		}
+		if got := h.Get(DatadogTagsHeader); got != tt.want {
+			t.Errorf("Inject(%v) with tags %q: tags = %q, want %q", tt.id, tt.tags, got, tt.want)
+		}
+		if got, err := (DatadogPropagator{}).Extract(h); err != nil || got != tt.id {
+			t.Errorf("Extract after Inject(%v) = %v, %v", tt.id, got, err)
+		}
+	}
+}
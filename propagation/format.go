@@ -0,0 +1,57 @@
+package propagation
+
+import (
+	"github.com/kataras/iris/core/errors"
+	"github.com/ximply/traceid"
+)
+
+// ErrUnknownFormat is returned by Inject and Extract for a Format they do not
+// know.
+var ErrUnknownFormat = errors.New("unknown propagation format %d")
+
+// Format selects a propagation format for Inject and Extract.
+type Format int
+
+// propagation formats
+const (
+	B3Format Format = iota
+	B3SingleFormat
+	TraceparentFormat
+	JaegerFormat
+	DatadogFormat
+)
+
+// Propagator returns the Propagator of f, and nil for an unknown Format.
+func (f Format) Propagator() Propagator {
+	switch f {
+	case B3Format:
+		return B3Propagator{}
+	case B3SingleFormat:
+		return B3Propagator{SingleHeader: true}
+	case TraceparentFormat:
+		return W3CPropagator{}
+	case JaegerFormat:
+		return JaegerPropagator{}
+	case DatadogFormat:
+		return DatadogPropagator{}
+	}
+	return nil
+}
+
+// Inject writes id to carrier in format f.
+func Inject(carrier TextCarrier, id traceid.TraceID, f Format) error {
+	p := f.Propagator()
+	if p == nil {
+		return ErrUnknownFormat.Format(int(f))
+	}
+	return p.Inject(id, carrier)
+}
+
+// Extract reads the trace id in format f from carrier.
+func Extract(carrier TextCarrier, f Format) (traceid.TraceID, error) {
+	p := f.Propagator()
+	if p == nil {
+		return traceid.TraceID{}, ErrUnknownFormat.Format(int(f))
+	}
+	return p.Extract(carrier)
+}
@@ -0,0 +1,89 @@
+package propagation
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/ximply/traceid"
+)
+
+func TestFormatConformance(t *testing.T) {
+	formats := []struct {
+		format Format
+		keys   []string
+	}{
+		{B3Format, []string{"x-b3-spanid", "x-b3-traceid"}},
+		{B3SingleFormat, []string{"b3"}},
+		{TraceparentFormat, []string{"traceparent"}},
+		{JaegerFormat, []string{"uber-trace-id"}},
+		{DatadogFormat, nil},
+	}
+	carriers := []struct {
+		name string
+		new  func() TextCarrier
+	}{
+		{"http", func() TextCarrier { return HTTPCarrier(http.Header{}) }},
+		{"map", func() TextCarrier { return MapCarrier(map[string]string{}) }},
+		{"multimap", func() TextCarrier { return MultiMapCarrier(map[string][]string{}) }},
+	}
+	ids := []traceid.TraceID{
+		{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736},
+		{Low: 0xa3ce929d0e0e4736},
+		{Low: 1},
+	}
+	for _, f := range formats {
+		for _, c := range carriers {
+			for _, id := range ids {
+				carrier := c.new()
+				if err := Inject(carrier, id, f.format); err != nil {
+					t.Errorf("format %d, %s carrier: Inject(%v): %v", f.format, c.name, id, err)
+					continue
+				}
+				if got, err := Extract(carrier, f.format); err != nil || got != id {
+					t.Errorf("format %d, %s carrier: Extract = %v, %v, want %v", f.format, c.name, got, err, id)
+				}
+				if f.keys == nil {
+					continue
+				}
+				keys := carrier.Keys()
+				for i := range keys {
+					keys[i] = strings.ToLower(keys[i])
+				}
+				sort.Strings(keys)
+				if len(keys) != len(f.keys) {
+					t.Errorf("format %d, %s carrier: keys = %v, want %v", f.format, c.name, keys, f.keys)
+					continue
+				}
+				for i := range keys {
+					if keys[i] != f.keys[i] {
+						t.Errorf("format %d, %s carrier: keys = %v, want %v", f.format, c.name, keys, f.keys)
+						break
+					}
+				}
+			}
+			carrier := c.new()
+			if _, err := Extract(carrier, f.format); err == nil {
+				t.Errorf("format %d, %s carrier: Extract of an empty carrier succeeded", f.format, c.name)
+			}
+			if err := Inject(carrier, traceid.TraceID{}, f.format); err == nil || !ErrZeroID.Equal(err) {
+				t.Errorf("format %d, %s carrier: Inject of zero id error = %v, want ErrZeroID", f.format, c.name, err)
+			}
+		}
+	}
+}
+
+func TestFormatUnknown(t *testing.T) {
+	f := Format(-1)
+	if p := f.Propagator(); p != nil {
+		t.Errorf("Propagator() = %v, want nil", p)
+	}
+	carrier := MapCarrier(map[string]string{})
+	if err := Inject(carrier, traceid.TraceID{Low: 1}, f); err == nil || !ErrUnknownFormat.Equal(err) {
+		t.Errorf("Inject error = %v, want ErrUnknownFormat", err)
+	}
+	if _, err := Extract(carrier, f); err == nil || !ErrUnknownFormat.Equal(err) {
+		t.Errorf("Extract error = %v, want ErrUnknownFormat", err)
+	}
+}
@@ -0,0 +1,44 @@
+package propagation
+
+import (
+	"github.com/kataras/iris/core/errors"
+	"github.com/ximply/traceid"
+)
+
+// RequestIDHeader is the de facto standard header of request ids.
+const RequestIDHeader = "X-Request-Id"
+
+// header errors
+var (
+	ErrNoHeader      = errors.New("header %q not found")
+	ErrInvalidHeader = errors.New("invalid trace id in header %q: %q")
+)
+
+// HeaderPropagator injects and extracts a trace id as the only value of a
+// single header such as RequestIDHeader.
+type HeaderPropagator struct {
+	Header string
+}
+
+// Inject writes the hex representation of id to the header.
+func (p HeaderPropagator) Inject(id traceid.TraceID, carrier Carrier) error {
+	if id.IsZero() {
+		return ErrZeroID
+	}
+	carrier.Set(p.Header, id.String())
+	return nil
+}
+
+// Extract reads the trace id from the header in any of the formats of
+// traceid.Parse.
+func (p HeaderPropagator) Extract(carrier Carrier) (traceid.TraceID, error) {
+	h := carrier.Get(p.Header)
+	if h == "" {
+		return traceid.TraceID{}, ErrNoHeader.Format(p.Header)
+	}
+	id, err := traceid.Parse(h)
+	if err != nil || id.IsZero() {
+		return traceid.TraceID{}, ErrInvalidHeader.Format(p.Header, h)
+	}
+	return id, nil
+}
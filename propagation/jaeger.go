@@ -0,0 +1,123 @@
+package propagation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/kataras/iris/core/errors"
+	"github.com/ximply/traceid"
+)
+
+// JaegerHeader is the header Jaeger propagates its span context in.
+const JaegerHeader = "uber-trace-id"
+
+// Jaeger errors
+var (
+	ErrNoJaeger      = errors.New("uber-trace-id header not found")
+	ErrInvalidJaeger = errors.New("invalid uber-trace-id header %q")
+)
+
+// JaegerFlags is the flags byte of the uber-trace-id header.
+type JaegerFlags byte
+
+// Jaeger flag bits
+const (
+	JaegerFlagSampled JaegerFlags = 0x01
+	JaegerFlagDebug   JaegerFlags = 0x02
+)
+
+// Sampled returns if the sampled bit is set.
+func (f JaegerFlags) Sampled() bool {
+	return f&JaegerFlagSampled != 0
+}
+
+// Debug returns if the debug bit is set.
+func (f JaegerFlags) Debug() bool {
+	return f&JaegerFlagDebug != 0
+}
+
+// JaegerPropagator injects and extracts the Jaeger uber-trace-id header of the
+// form {trace-id}:{span-id}:{parent-span-id}:{flags}.
+type JaegerPropagator struct{}
+
+// Inject writes id to the uber-trace-id header of carrier with a random span
+// id and no flags set.
+func (p JaegerPropagator) Inject(id traceid.TraceID, carrier Carrier) error {
+	return p.InjectContext(context.Background(), id, carrier)
+}
+
+// InjectContext writes id to the uber-trace-id header of carrier with the
+// span id of ctx and a zero parent id, see ContextInjector. The sampled flag
+// is only set if ctx says so.
+func (JaegerPropagator) InjectContext(ctx context.Context, id traceid.TraceID, carrier Carrier) error {
+	if id.IsZero() {
+		return ErrZeroID
+	}
+	spanID, sampled := spanFromContext(ctx)
+	var flags JaegerFlags
+	if sampled != nil && *sampled {
+		flags = JaegerFlagSampled
+	}
+	carrier.Set(JaegerHeader, FormatUberTraceID(id, spanID, 0, flags))
+	return nil
+}
+
+// Extract reads the trace id from the uber-trace-id header of carrier, see
+// ParseUberTraceID.
+func (JaegerPropagator) Extract(carrier Carrier) (traceid.TraceID, error) {
+	h := carrier.Get(JaegerHeader)
+	if h == "" {
+		return traceid.TraceID{}, ErrNoJaeger
+	}
+	id, _, _, _, err := ParseUberTraceID(h)
+	if err != nil {
+		return traceid.TraceID{}, err
+	}
+	return id, nil
+}
+
+// FormatUberTraceID returns the uber-trace-id value for the given ids in the
+// padded form the Jaeger clients write: the trace id as String renders it,
+// span and parent id as 16 hex characters and the flags in hex.
+func FormatUberTraceID(id traceid.TraceID, spanID, parentID traceid.SpanID, flags JaegerFlags) string {
+	return fmt.Sprintf("%s:%s:%s:%x", id, spanID, parentID, byte(flags))
+}
+
+// ParseUberTraceID parses an uber-trace-id value. Like Jaeger it accepts hex
+// segments which are not zero padded, trace ids of up to 32 characters and a
+// zero parent id. Zero trace and span ids are rejected.
+func ParseUberTraceID(h string) (id traceid.TraceID, spanID, parentID traceid.SpanID, flags JaegerFlags, err error) {
+	invalid := ErrInvalidJaeger.Format(h)
+	parts := strings.Split(h, ":")
+	if len(parts) != 4 {
+		err = invalid
+		return
+	}
+	if id, err = traceid.ParseHex(parts[0]); err != nil {
+		err = invalid
+		return
+	}
+	span, err := strconv.ParseUint(parts[1], 16, 64)
+	if err != nil {
+		err = invalid
+		return
+	}
+	parent, err := strconv.ParseUint(parts[2], 16, 64)
+	if err != nil {
+		err = invalid
+		return
+	}
+	f, err := strconv.ParseUint(parts[3], 16, 8)
+	if err != nil {
+		err = invalid
+		return
+	}
+	if id.IsZero() || span == 0 {
+		err = invalid
+		return
+	}
+	spanID, parentID, flags = traceid.SpanID(span), traceid.SpanID(parent), JaegerFlags(f)
+	return
+}
@@ -0,0 +1,99 @@
+package propagation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ximply/traceid"
+)
+
+func TestParseUberTraceID(t *testing.T) {
+	tests := []struct {
+		in             string
+		id             traceid.TraceID
+		span, parent   traceid.SpanID
+		sampled, debug bool
+		canonical      string
+	}{
+		{
+			in:        "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0:1",
+			id:        traceid.TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736},
+			span:      0x00f067aa0ba902b7,
+			sampled:   true,
+			canonical: "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0000000000000000:1",
+		},
+		{
+			in:        "d3fe7a1b4fbc1e5a:d3fe7a1b4fbc1e5a:0:1",
+			id:        traceid.TraceID{Low: 0xd3fe7a1b4fbc1e5a},
+			span:      0xd3fe7a1b4fbc1e5a,
+			sampled:   true,
+			canonical: "d3fe7a1b4fbc1e5a:d3fe7a1b4fbc1e5a:0000000000000000:1",
+		},
+		{
+			in:        "abc:def:1:3",
+			id:        traceid.TraceID{Low: 0xabc},
+			span:      0xdef,
+			parent:    1,
+			sampled:   true,
+			debug:     true,
+			canonical: "0000000000000abc:0000000000000def:0000000000000001:3",
+		},
+		{
+			in:        "1:2:0:0",
+			id:        traceid.TraceID{Low: 1},
+			span:      2,
+			canonical: "0000000000000001:0000000000000002:0000000000000000:0",
+		},
+	}
+	for _, tt := range tests {
+		id, span, parent, flags, err := ParseUberTraceID(tt.in)
+		if err != nil {
+			t.Errorf("ParseUberTraceID(%q): %v", tt.in, err)
+			continue
+		}
+		if id != tt.id || span != tt.span || parent != tt.parent || flags.Sampled() != tt.sampled || flags.Debug() != tt.debug {
+			t.Errorf("ParseUberTraceID(%q) = %v, %v, %v, %x", tt.in, id, span, parent, byte(flags))
+		}
+		if got := FormatUberTraceID(id, span, parent, flags); got != tt.canonical {
+			t.Errorf("FormatUberTraceID(%q) = %q, want %q", tt.in, got, tt.canonical)
+		}
+	}
+}
+
+func TestParseUberTraceIDInvalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0",
+		"4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0:1:0",
+		"xyz:00f067aa0ba902b7:0:1",
+		"4bf92f3577b34da6a3ce929d0e0e47360:00f067aa0ba902b7:0:1",
+		"4bf92f3577b34da6:xyz:0:1",
+		"4bf92f3577b34da6:00f067aa0ba902b7:xyz:1",
+		"4bf92f3577b34da6:00f067aa0ba902b7:0:100",
+		"0:00f067aa0ba902b7:0:1",
+		"4bf92f3577b34da6:0:0:1",
+	} {
+		if _, _, _, _, err := ParseUberTraceID(in); err == nil || !ErrInvalidJaeger.Equal(err) {
+			t.Errorf("ParseUberTraceID(%q) error = %v, want ErrInvalidJaeger", in, err)
+		}
+	}
+}
+
+func TestJaegerPropagator(t *testing.T) {
+	h := http.Header{}
+	id := traceid.TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	ctx := traceid.NewSampledContext(traceid.NewSpanContext(context.Background(), 0x00f067aa0ba902b7), true)
+	if err := (JaegerPropagator{}).InjectContext(ctx, id, h); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := h.Get(JaegerHeader), "4bf92f3577b34da6a3ce929d0e0e4736:00f067aa0ba902b7:0000000000000000:1"; got != want {
+		t.Errorf("header = %q, want %q", got, want)
+	}
+	if got, err := (JaegerPropagator{}).Extract(h); err != nil || got != id {
+		t.Errorf("Extract = %v, %v, want %v", got, err, id)
+	}
+	if _, err := (JaegerPropagator{}).Extract(http.Header{}); err == nil || !ErrNoJaeger.Equal(err) {
+		t.Errorf("Extract of empty header error = %v, want ErrNoJaeger", err)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Package propagation injects trace ids into and extracts them from the headers
+used by the common tracing systems.
+*/
+package propagation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"io"
+
+	"github.com/kataras/iris/core/errors"
+	"github.com/ximply/traceid"
+)
+
+// propagation errors
+var (
+	ErrZeroID       = errors.New("cannot propagate a zero id")
+	ErrNoPropagator = errors.New("no propagator configured")
+)
+
+// Carrier is what a Propagator writes to and reads from. http.Header
+// implements it.
+type Carrier interface {
+	Set(key, value string)
+	Get(key string) string
+}
+
+// Propagator injects trace ids into and extracts them from a Carrier in one
+// particular format.
+type Propagator interface {
+	Inject(id traceid.TraceID, c Carrier) error
+	Extract(c Carrier) (traceid.TraceID, error)
+}
+
+// ContextInjector is implemented by Propagators whose format carries a span
+// id and sampling decision next to the trace id. InjectContext takes both from
+// ctx, see traceid.NewSpanContext and traceid.NewSampledContext: without a
+// span id a random one is sent, without a sampling decision it is left open.
+type ContextInjector interface {
+	InjectContext(ctx context.Context, id traceid.TraceID, c Carrier) error
+}
+
+// InjectContext writes id to c with p, through p.InjectContext if p is a
+// ContextInjector and p.Inject otherwise.
+func InjectContext(ctx context.Context, p Propagator, id traceid.TraceID, c Carrier) error {
+	if ci, ok := p.(ContextInjector); ok {
+		return ci.InjectContext(ctx, id, c)
+	}
+	return p.Inject(id, c)
+}
+
+// spanFromContext returns the span id of ctx, or a random one if there is
+// none, and the sampling decision of ctx, nil if it is open.
+func spanFromContext(ctx context.Context) (traceid.SpanID, *bool) {
+	spanID, ok := traceid.SpanIDFromContext(ctx)
+	if !ok || spanID.IsZero() {
+		spanID = randomSpanID()
+	}
+	var sampled *bool
+	if s, ok := traceid.SampledFromContext(ctx); ok {
+		sampled = &s
+	}
+	return spanID, sampled
+}
+
+// randomSpanID returns a non-zero span id read from crypto/rand.
+func randomSpanID() (spanID traceid.SpanID) {
+	var b [8]byte
+	for spanID.IsZero() {
+		if _, err := io.ReadFull(rand.Reader, b[:]); err != nil {
+			panic("propagation: reading from crypto/rand failed: " + err.Error())
+		}
+		spanID = traceid.SpanID(binary.BigEndian.Uint64(b[:]))
+	}
+	return
+}
+
+// MultiPropagator composes several propagation formats.
+type MultiPropagator []Propagator
+
+// NewMultiPropagator returns a Propagator which injects all formats of ps and
+// extracts the first of them found in a Carrier.
+func NewMultiPropagator(ps ...Propagator) MultiPropagator {
+	return MultiPropagator(ps)
+}
+
+// Inject writes id in every format to c, see InjectContext.
+func (m MultiPropagator) Inject(id traceid.TraceID, c Carrier) error {
+	return m.InjectContext(context.Background(), id, c)
+}
+
+// InjectContext writes id in every format to c, with the span id and sampling
+// decision of ctx where the format carries them. It returns the first error
+// encountered but still tries the remaining formats.
+func (m MultiPropagator) InjectContext(ctx context.Context, id traceid.TraceID, c Carrier) (err error) {
+	for _, p := range m {
+		if e := InjectContext(ctx, p, id, c); e != nil && err == nil {
+			err = e
+		}
+	}
+	return
+}
+
+// Extract returns the trace id of the first format which extracts without an
+// error, or else the error of the first format.
+func (m MultiPropagator) Extract(c Carrier) (traceid.TraceID, error) {
+	var first error
+	for _, p := range m {
+		id, err := p.Extract(c)
+		if err == nil {
+			return id, nil
+		}
+		if first == nil {
+			first = err
+		}
+	}
+	if first == nil {
+		first = ErrNoPropagator
+	}
+	return traceid.TraceID{}, first
+}
+
+// isLowerHex returns if s consists of lowercase hex characters only.
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
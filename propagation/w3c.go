@@ -0,0 +1,114 @@
+package propagation
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/kataras/iris/core/errors"
+	"github.com/ximply/traceid"
+)
+
+// TraceparentHeader is the W3C Trace Context header carrying the trace id.
+const TraceparentHeader = "traceparent"
+
+// W3C Trace Context errors
+var (
+	ErrNoTraceparent      = errors.New("traceparent header not found")
+	ErrInvalidTraceparent = errors.New("invalid traceparent header %q")
+)
+
+// W3CPropagator injects and extracts the W3C Trace Context traceparent header
+// of the form 00-<32 hex trace id>-<16 hex parent id>-<2 hex flags>.
+type W3CPropagator struct{}
+
+// Inject writes id to the traceparent header of carrier with a random parent
+// id and the sampled flag unset.
+func (p W3CPropagator) Inject(id traceid.TraceID, carrier Carrier) error {
+	return p.InjectContext(context.Background(), id, carrier)
+}
+
+// InjectContext writes id to the traceparent header of carrier with the span
+// id of ctx as parent id, see ContextInjector. traceparent cannot leave the
+// sampling decision open, so the sampled flag is only set if ctx says so.
+func (p W3CPropagator) InjectContext(ctx context.Context, id traceid.TraceID, carrier Carrier) error {
+	spanID, sampled := spanFromContext(ctx)
+	return p.InjectSpan(id, spanID, sampled != nil && *sampled, carrier)
+}
+
+// Extract reads the trace id from the traceparent header of carrier.
+func (p W3CPropagator) Extract(carrier Carrier) (traceid.TraceID, error) {
+	id, _, _, err := p.ExtractSpan(carrier)
+	return id, err
+}
+
+// InjectSpan writes the traceparent header for the given ids to carrier.
+func (W3CPropagator) InjectSpan(id traceid.TraceID, spanID traceid.SpanID, sampled bool, carrier Carrier) error {
+	if id.IsZero() || spanID.IsZero() {
+		return ErrZeroID
+	}
+	carrier.Set(TraceparentHeader, FormatTraceparent(id, spanID, sampled))
+	return nil
+}
+
+// ExtractSpan reads the traceparent header from carrier, see
+// ParseTraceparent.
+func (W3CPropagator) ExtractSpan(carrier Carrier) (id traceid.TraceID, spanID traceid.SpanID, sampled bool, err error) {
+	h := carrier.Get(TraceparentHeader)
+	if h == "" {
+		err = ErrNoTraceparent
+		return
+	}
+	var flags byte
+	id, spanID, flags, err = ParseTraceparent(h)
+	sampled = flags&FlagSampled != 0
+	return
+}
+
+// FlagSampled is the trace flag telling the caller recorded the trace.
+const FlagSampled byte = 0x01
+
+// FormatTraceparent returns the version 00 traceparent value for the given
+// ids. Zero ids are not valid in a traceparent, callers are expected to check.
+func FormatTraceparent(id traceid.TraceID, spanID traceid.SpanID, sampled bool) string {
+	flags := "00"
+	if sampled {
+		flags = "01"
+	}
+	return fmt.Sprintf("00-%016x%016x-%s-%s", id.High, id.Low, spanID, flags)
+}
+
+// ParseTraceparent parses a traceparent value. It rejects upper case hex,
+// version ff and zero ids. Versions newer than 00 are parsed as far as this
+// version of the specification defines them and may carry further fields
+// after the flags.
+func ParseTraceparent(h string) (id traceid.TraceID, spanID traceid.SpanID, flags byte, err error) {
+	invalid := ErrInvalidTraceparent.Format(h)
+	if len(h) < 55 || h[2] != '-' || h[35] != '-' || h[52] != '-' {
+		err = invalid
+		return
+	}
+	version, traceID, parentID, flagsHex := h[:2], h[3:35], h[36:52], h[53:55]
+	if !isLowerHex(version) || version == "ff" ||
+		!isLowerHex(traceID) || !isLowerHex(parentID) || !isLowerHex(flagsHex) {
+		err = invalid
+		return
+	}
+	if version == "00" && len(h) != 55 || len(h) > 55 && h[55] != '-' {
+		err = invalid
+		return
+	}
+	if id, err = traceid.TraceIDFromHex(traceID); err != nil {
+		return
+	}
+	if err = spanID.UnmarshalText([]byte(parentID)); err != nil {
+		return
+	}
+	if id.IsZero() || spanID.IsZero() {
+		err = invalid
+		return
+	}
+	f, _ := strconv.ParseUint(flagsHex, 16, 8)
+	flags = byte(f)
+	return
+}
@@ -0,0 +1,88 @@
+package propagation
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ximply/traceid"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	specID := traceid.TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	tests := []struct {
+		in    string
+		id    traceid.TraceID
+		span  traceid.SpanID
+		flags byte
+	}{
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", specID, 0x00f067aa0ba902b7, 0x01},
+		{"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", specID, 0x00f067aa0ba902b7, 0x00},
+		{"00-00000000000000000000000000000001-0000000000000001-03", traceid.TraceID{Low: 1}, 1, 0x03},
+		{"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", specID, 0x00f067aa0ba902b7, 0x01},
+		{"cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-09-what-the-future-holds", specID, 0x00f067aa0ba902b7, 0x09},
+	}
+	for _, tt := range tests {
+		id, span, flags, err := ParseTraceparent(tt.in)
+		if err != nil {
+			t.Errorf("ParseTraceparent(%q): %v", tt.in, err)
+			continue
+		}
+		if id != tt.id || span != tt.span || flags != tt.flags {
+			t.Errorf("ParseTraceparent(%q) = %v, %v, %02x, want %v, %v, %02x", tt.in, id, span, flags, tt.id, tt.span, tt.flags)
+		}
+	}
+}
+
+func TestParseTraceparentInvalid(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00F067AA0BA902B7-01",
+		"0A-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-0g",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"00_4bf92f3577b34da6a3ce929d0e0e4736_00f067aa0ba902b7_01",
+		"01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01extra",
+		"00-4bf92f3577b34da6a3ce929d0e0e473-600f067aa0ba902b7-01",
+	} {
+		if _, _, _, err := ParseTraceparent(in); err == nil || !ErrInvalidTraceparent.Equal(err) {
+			t.Errorf("ParseTraceparent(%q) error = %v, want ErrInvalidTraceparent", in, err)
+		}
+	}
+}
+
+func TestFormatTraceparent(t *testing.T) {
+	id := traceid.TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	if got, want := FormatTraceparent(id, 0x00f067aa0ba902b7, true), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"; got != want {
+		t.Errorf("FormatTraceparent = %q, want %q", got, want)
+	}
+	if got, want := FormatTraceparent(traceid.TraceID{Low: 0xab}, 1, false), "00-000000000000000000000000000000ab-0000000000000001-00"; got != want {
+		t.Errorf("FormatTraceparent = %q, want %q", got, want)
+	}
+}
+
+func TestW3CPropagator(t *testing.T) {
+	h := http.Header{}
+	id := traceid.TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	ctx := traceid.NewSampledContext(traceid.NewSpanContext(context.Background(), 0x00f067aa0ba902b7), true)
+	if err := (W3CPropagator{}).InjectContext(ctx, id, h); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := h.Get(TraceparentHeader), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"; got != want {
+		t.Errorf("header = %q, want %q", got, want)
+	}
+	if got, err := (W3CPropagator{}).Extract(h); err != nil || got != id {
+		t.Errorf("Extract = %v, %v, want %v", got, err, id)
+	}
+	if _, err := (W3CPropagator{}).Extract(http.Header{}); err == nil || !ErrNoTraceparent.Equal(err) {
+		t.Errorf("Extract of empty header error = %v, want ErrNoTraceparent", err)
+	}
+	if err := (W3CPropagator{}).Inject(traceid.TraceID{}, h); err == nil || !ErrZeroID.Equal(err) {
+		t.Errorf("Inject of zero id error = %v, want ErrZeroID", err)
+	}
+}
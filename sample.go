@@ -0,0 +1,25 @@
+package traceid
+
+// sampleBits is the number of low bits of Low ShouldSample decides on, the
+// same 56 bits the OpenTelemetry trace id ratio sampler uses.
+const sampleBits = 56
+
+// SampleMask selects the bits of Low ShouldSample decides on.
+const SampleMask = 1<<sampleBits - 1
+
+// ShouldSample returns the head based sampling decision for id at rate, a
+// fraction between 0 and 1. The decision is a pure function of the low 56
+// bits of Low, so every service in a call chain reaches the same one without
+// coordinating: id is sampled when those bits, read as an unsigned number,
+// are below rate * 2^56. For ids whose low 56 bits are uniformly random the
+// share of sampled ids therefore matches rate. A rate of 0 or less never
+// samples, a rate of 1 or more always does.
+func ShouldSample(id TraceID, rate float64) bool {
+	switch {
+	case rate <= 0:
+		return false
+	case rate >= 1:
+		return true
+	}
+	return id.Low&SampleMask < uint64(rate*(1<<sampleBits))
+}
@@ -0,0 +1,29 @@
+package traceid
+
+import "testing"
+
+func TestShouldSample(t *testing.T) {
+	half := uint64(1) << (sampleBits - 1)
+	tests := []struct {
+		low  uint64
+		rate float64
+		want bool
+	}{
+		{0, 0, false},
+		{0, -1, false},
+		{SampleMask, 1, true},
+		{SampleMask, 2, true},
+		{half - 1, 0.5, true},
+		{half, 0.5, false},
+		{^uint64(SampleMask) | (half - 1), 0.5, true},
+		{^uint64(SampleMask) | half, 0.5, false},
+		{0, 0.01, true},
+		{SampleMask, 0.99, false},
+	}
+	for _, tt := range tests {
+		id := TraceID{High: ^uint64(0), Low: tt.low}
+		if got := ShouldSample(id, tt.rate); got != tt.want {
+			t.Errorf("ShouldSample(%v, %v) = %v, want %v", id, tt.rate, got, tt.want)
+		}
+	}
+}
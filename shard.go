@@ -0,0 +1,14 @@
+package traceid
+
+// ShardOf returns the shard id stored in the top bits bits of High, the layout
+// written by idgenerator.NewSharded: shard id s and width w give
+// High = s<<(64-w) | (High & (1<<(64-w) - 1)). bits is clamped to [0, 16].
+func ShardOf(id TraceID, bits uint) uint16 {
+	if bits == 0 {
+		return 0
+	}
+	if bits > 16 {
+		bits = 16
+	}
+	return uint16(id.High >> (64 - bits))
+}
@@ -0,0 +1,30 @@
+//go:build go1.21
+// +build go1.21
+
+package traceid
+
+import "log/slog"
+
+// SlogKey is the conventional attribute key of trace ids in structured logs.
+const SlogKey = "trace_id"
+
+// LogValue implements slog.LogValuer, rendering the hex representation only
+// when the record is handled. The zero TraceID renders as an empty string so
+// log filters can tell "no trace" from a value worth indexing.
+func (t TraceID) LogValue() slog.Value {
+	if t.IsZero() {
+		return slog.StringValue("")
+	}
+	return slog.StringValue(t.String())
+}
+
+// SlogAttr returns id as an attribute under SlogKey.
+func SlogAttr(id TraceID) slog.Attr {
+	return slog.Any(SlogKey, id)
+}
+
+// TraceIDAttr returns id as a string attribute under key, rendered like
+// LogValue. Prefer SlogAttr unless an existing log schema dictates the key.
+func TraceIDAttr(key string, id TraceID) slog.Attr {
+	return slog.Attr{Key: key, Value: id.LogValue()}
+}
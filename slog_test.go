@@ -0,0 +1,35 @@
+//go:build go1.21
+// +build go1.21
+
+package traceid
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestSlog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	id := TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	logger.Info("request",
+		SlogAttr(id),
+		TraceIDAttr("parent", TraceID{Low: 0xab}),
+		slog.Any("zero", TraceID{}),
+	)
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("%v: %s", err, buf.Bytes())
+	}
+	for key, want := range map[string]string{
+		SlogKey:  "4bf92f3577b34da6a3ce929d0e0e4736",
+		"parent": "00000000000000ab",
+		"zero":   "",
+	} {
+		if got, ok := record[key]; !ok || got != want {
+			t.Errorf("%s = %#v, want %q", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package traceid
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// SpanID is a 64 bit span identifier.
+type SpanID uint64
+
+// IsZero returns if SpanID has zero value, which is never a valid span id.
+func (s SpanID) IsZero() bool {
+	return s == 0
+}
+
+// String outputs the SpanID as 16 character hex string.
+func (s SpanID) String() string {
+	return fmt.Sprintf("%016x", uint64(s))
+}
+
+// Bytes returns the SpanID as 8 big-endian bytes.
+func (s SpanID) Bytes() (b [8]byte) {
+	binary.BigEndian.PutUint64(b[:], uint64(s))
+	return
+}
+
+// MarshalText implements encoding.TextMarshaler using the hex representation.
+func (s SpanID) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, expecting 16 hex
+// characters.
+func (s *SpanID) UnmarshalText(text []byte) error {
+	id, err := SpanIDFromHex(string(text))
+	if err != nil {
+		return err
+	}
+	*s = id
+	return nil
+}
+
+// SpanIDFromHex returns the SpanID from exactly 16 hex characters.
+func SpanIDFromHex(h string) (SpanID, error) {
+	if len(h) != 16 {
+		return 0, ErrValidIDRequired
+	}
+	id, err := hexToUint64(h, 0)
+	return SpanID(id), err
+}
+
+// ParseSpanID returns the SpanID from 1 to 16 hex characters, taking shorter
+// strings as left padded with zeros like ParseHex.
+func ParseSpanID(h string) (SpanID, error) {
+	if h == "" || len(h) > 16 {
+		return 0, ErrValidIDRequired
+	}
+	id, err := hexToUint64(h, 0)
+	return SpanID(id), err
+}
@@ -0,0 +1,68 @@
+package traceid
+
+import (
+	"testing"
+
+	"github.com/kataras/iris/core/errors"
+)
+
+func TestSpanIDHex(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   SpanID
+		exact  errors.Error
+		padded errors.Error
+	}{
+		{in: "00f067aa0ba902b7", want: 0x00f067aa0ba902b7},
+		{in: "00F067AA0BA902B7", want: 0x00f067aa0ba902b7},
+		{in: "ffffffffffffffff", want: SpanID(^uint64(0))},
+		{in: "ab", want: 0xab, exact: ErrValidIDRequired},
+		{in: "", exact: ErrValidIDRequired, padded: ErrValidIDRequired},
+		{in: "00f067aa0ba902b70", exact: ErrValidIDRequired, padded: ErrValidIDRequired},
+		{in: "00f067aa0ba902bx", exact: ErrTraceIDHex, padded: ErrTraceIDHex},
+		{in: "xy", exact: ErrValidIDRequired, padded: ErrTraceIDHex},
+	}
+	for _, tt := range tests {
+		got, err := SpanIDFromHex(tt.in)
+		if tt.exact.NotEmpty() {
+			if err == nil || !tt.exact.Equal(err) {
+				t.Errorf("SpanIDFromHex(%q) error = %v, want %v", tt.in, err, tt.exact)
+			}
+		} else if err != nil || got != tt.want {
+			t.Errorf("SpanIDFromHex(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+		got, err = ParseSpanID(tt.in)
+		if tt.padded.NotEmpty() {
+			if err == nil || !tt.padded.Equal(err) {
+				t.Errorf("ParseSpanID(%q) error = %v, want %v", tt.in, err, tt.padded)
+			}
+		} else if err != nil || got != tt.want {
+			t.Errorf("ParseSpanID(%q) = %v, %v, want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestSpanIDText(t *testing.T) {
+	id := SpanID(0xab)
+	if got := id.String(); got != "00000000000000ab" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := id.Bytes(); got != [8]byte{7: 0xab} {
+		t.Errorf("Bytes() = %x", got)
+	}
+	text, err := id.MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got SpanID
+	if err := got.UnmarshalText(text); err != nil || got != id {
+		t.Errorf("UnmarshalText(%s) = %v, %v, want %v", text, got, err, id)
+	}
+	got = 1
+	if err := got.UnmarshalText([]byte("ab")); err == nil || got != 1 {
+		t.Errorf("UnmarshalText(ab) = %v, %v, want an error and no change", got, err)
+	}
+	if !SpanID(0).IsZero() || id.IsZero() {
+		t.Error("IsZero is wrong")
+	}
+}
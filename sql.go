@@ -0,0 +1,81 @@
+package traceid
+
+import (
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/kataras/iris/core/errors"
+)
+
+// database/sql errors, use Equal to match them
+var (
+	ErrScanType = errors.New("cannot scan %T into a TraceID")
+)
+
+// Scan implements sql.Scanner. It accepts NULL as the zero TraceID, hex text
+// as accepted by TraceIDFromHex, either as string or as []byte the way many
+// drivers return text columns, and the 16 bytes returned by Bytes, as stored
+// in a bytea column. 16 bytes which are all hex characters are read as the
+// text of a 64 bit id; scan into Binary to read such columns as raw bytes.
+func (t *TraceID) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*t = TraceID{}
+		return nil
+	case []byte:
+		if len(v) != 16 || isHexBytes(v) {
+			return t.Scan(string(v))
+		}
+		var b [16]byte
+		copy(b[:], v)
+		*t = TraceIDFromBytes(b)
+		return nil
+	case string:
+		tID, err := TraceIDFromHex(v)
+		if err != nil {
+			return err
+		}
+		*t = tID
+		return nil
+	}
+	return ErrScanType.Format(src)
+}
+
+// Value implements driver.Valuer, storing the TraceID as 32 hex characters
+// to fit a char(32) column.
+func (t TraceID) Value() (driver.Value, error) {
+	return fmt.Sprintf("%016x%016x", t.High, t.Low), nil
+}
+
+// Binary stores a TraceID as its 16 bytes rather than as hex text:
+//
+//	db.Exec("INSERT INTO traces (id) VALUES ($1)", traceid.Binary(id))
+//	row.Scan((*traceid.Binary)(&id))
+type Binary TraceID
+
+// Scan implements sql.Scanner, accepting what TraceID.Scan accepts except
+// that 16 bytes are always read as raw bytes.
+func (b *Binary) Scan(src interface{}) error {
+	if v, ok := src.([]byte); ok && len(v) == 16 {
+		var raw [16]byte
+		copy(raw[:], v)
+		*b = Binary(TraceIDFromBytes(raw))
+		return nil
+	}
+	return (*TraceID)(b).Scan(src)
+}
+
+// isHexBytes returns if b consists of hex characters of either case only.
+func isHexBytes(b []byte) bool {
+	for _, c := range b {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// Value implements driver.Valuer, returning the 16 bytes of Bytes.
+func (b Binary) Value() (driver.Value, error) {
+	return TraceID(b).MarshalBinary()
+}
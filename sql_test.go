@@ -0,0 +1,174 @@
+package traceid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"sync"
+	"testing"
+)
+
+// fakeDriver is a database/sql driver storing a single column per database
+// name: "insert" appends the first argument, "select" returns all rows.
+type fakeDriver struct {
+	mtx    sync.Mutex
+	tables map[string][]driver.Value
+}
+
+var fake = &fakeDriver{tables: make(map[string][]driver.Value)}
+
+func init() {
+	sql.Register("traceidfake", fake)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{d: d, name: name}, nil
+}
+
+type fakeConn struct {
+	d    *fakeDriver
+	name string
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{c: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+type fakeStmt struct {
+	c     *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.c.d.mtx.Lock()
+	defer s.c.d.mtx.Unlock()
+	s.c.d.tables[s.c.name] = append(s.c.d.tables[s.c.name], args[0])
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.c.d.mtx.Lock()
+	defer s.c.d.mtx.Unlock()
+	return &fakeRows{values: append([]driver.Value(nil), s.c.d.tables[s.c.name]...)}, nil
+}
+
+type fakeRows struct {
+	values []driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return []string{"id"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	dest[0], r.values = r.values[0], r.values[1:]
+	return nil
+}
+
+// openFakeDB opens an empty database named after the test.
+func openFakeDB(t *testing.T) *sql.DB {
+	fake.mtx.Lock()
+	delete(fake.tables, t.Name())
+	fake.mtx.Unlock()
+	db, err := sql.Open("traceidfake", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func TestScanValue(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+	want := []TraceID{
+		{},
+		{Low: 0xab},
+		{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736},
+		{High: 0x3030303030303030, Low: 0x3030303030306162},
+	}
+	for _, id := range want {
+		if _, err := db.Exec("insert", id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, v := range []interface{}{nil, []byte("00000000000000ab"), "00000000000000ab", []byte("4BF92F3577B34DA6A3CE929D0E0E4736")} {
+		if _, err := db.Exec("insert", v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want = append(want, TraceID{}, TraceID{Low: 0xab}, TraceID{Low: 0xab}, TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736})
+
+	rows, err := db.Query("select")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	var got []TraceID
+	for rows.Next() {
+		var id TraceID
+		if err := rows.Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, id)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d rows, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("row %d: got %#v, want %#v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestScanBinary(t *testing.T) {
+	db := openFakeDB(t)
+	defer db.Close()
+	want := []TraceID{
+		{Low: 0xab},
+		{High: 0x3030303030303030, Low: 0x3030303030306162}, // the bytes of "00000000000000ab"
+	}
+	for _, id := range want {
+		if _, err := db.Exec("insert", Binary(id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rows, err := db.Query("select")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for i := 0; rows.Next(); i++ {
+		var id TraceID
+		if err := rows.Scan((*Binary)(&id)); err != nil {
+			t.Fatal(err)
+		}
+		if id != want[i] {
+			t.Errorf("row %d: got %#v, want %#v", i, id, want[i])
+		}
+	}
+}
+
+func TestScanErrors(t *testing.T) {
+	var id TraceID
+	if err := id.Scan(42); err == nil || !ErrScanType.Equal(err) {
+		t.Errorf("Scan(42) = %v, want ErrScanType", err)
+	}
+	if err := id.Scan("xyz"); err == nil {
+		t.Error("Scan(\"xyz\") succeeded")
+	}
+	if err := id.Scan([]byte("not hex but 16 b")); err != nil {
+		t.Errorf("Scan of 16 raw bytes: %v", err)
+	}
+}
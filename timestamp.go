@@ -0,0 +1,25 @@
+package traceid
+
+import (
+	"time"
+)
+
+// range of timestamps ExtractTimestamp considers plausible
+var (
+	minTimestamp = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+	maxTimestamp = time.Date(2100, 1, 1, 0, 0, 0, 0, time.UTC).Unix()
+)
+
+// ExtractTimestamp returns the time embedded in the upper 32 bits of High by
+// the timestamped generator of package idgenerator, which stores Unix seconds
+// there and random bits in the lower 32 bits. Every value of the random bits
+// is plausible, so the format is detected by the timestamp alone: it reports
+// false unless the seconds fall in the years 2000 to 2099. Ids generated with
+// a custom epoch are not recognized, use idgenerator.TimestampOf for those.
+func (t TraceID) ExtractTimestamp() (time.Time, bool) {
+	sec := int64(t.High >> 32)
+	if sec < minTimestamp || sec >= maxTimestamp {
+		return time.Time{}, false
+	}
+	return time.Unix(sec, 0), true
+}
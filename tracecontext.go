@@ -0,0 +1,34 @@
+package traceid
+
+// TraceContext is the state of Zipkin's trace model travelling with a trace:
+// the ids and the sampling decision. A nil Sampled leaves the decision to the
+// receiver. Debug forces sampling and implies Sampled.
+type TraceContext struct {
+	TraceID      TraceID
+	SpanID       SpanID
+	ParentSpanID SpanID
+	Sampled      *bool
+	Debug        bool
+}
+
+// WithSampled returns a copy of c with the sampling decision set.
+func (c TraceContext) WithSampled(sampled bool) TraceContext {
+	c.Sampled = &sampled
+	return c
+}
+
+// WithDebug returns a copy of c with the debug flag set. Setting it also
+// marks c as sampled.
+func (c TraceContext) WithDebug(debug bool) TraceContext {
+	c.Debug = debug
+	if debug {
+		c = c.WithSampled(true)
+	}
+	return c
+}
+
+// IsSampled returns if the trace is to be recorded, and false if the
+// decision is left open.
+func (c TraceContext) IsSampled() bool {
+	return c.Debug || c.Sampled != nil && *c.Sampled
+}
@@ -1,8 +1,12 @@
 package traceid
 
 import (
+	"encoding/binary"
+	"encoding/json"
 	"fmt"
-	"strconv"
+	"io"
+	"sort"
+
 	"github.com/kataras/iris/core/errors"
 )
 
@@ -13,6 +17,16 @@ var (
 	ErrValidDurationRequired = errors.New("valid duration required")
 )
 
+// parse errors, use Equal to match them as they carry the offending input
+var (
+	ErrTraceIDLength = errors.New("trace id must be 16 or 32 hex characters, got %d")
+	ErrTraceIDHex    = errors.New("invalid hex character %q at position %d")
+	ErrHexEmpty      = errors.New("empty trace id")
+	ErrHexTooLong    = errors.New("trace id longer than 32 hex characters: %d")
+	ErrBinaryLength  = errors.New("cannot read a TraceID from %d bytes, want 8 or 16")
+	ErrShortBuffer   = errors.New("cannot write a TraceID to %d bytes, want 16")
+)
+
 // TraceID is a 128 bit number internally stored as 2x uint64 (high & low).
 // In case of 64 bit traceIDs, the value can be found in Low.
 type TraceID struct {
@@ -20,12 +34,24 @@ type TraceID struct {
 	Low  uint64
 }
 
+// NewTraceID returns the TraceID of the given halves. It is the same as the
+// composite literal and meant for callers preferring not to name the fields.
+func NewTraceID(high, low uint64) TraceID {
+	return TraceID{High: high, Low: low}
+}
+
 // Empty returns if TraceID has zero value.
 func (t TraceID) Empty() bool {
 	return t.Low == 0 && t.High == 0
 }
 
-// String outputs the 128-bit traceID as hex string.
+// IsZero returns if TraceID has zero value, which is never a valid trace id.
+func (t TraceID) IsZero() bool {
+	return t.Empty()
+}
+
+// String outputs the 128-bit traceID as hex string: 32 zero padded lowercase
+// hex characters, or 16 if High is zero, the same as zipkin-go.
 func (t TraceID) String() string {
 	if t.High == 0 {
 		return fmt.Sprintf("%016x", t.Low)
@@ -33,19 +59,194 @@ func (t TraceID) String() string {
 	return fmt.Sprintf("%016x%016x", t.High, t.Low)
 }
 
-// TraceIDFromHex returns the TraceID from a hex string.
+// Equal returns if t and other hold the same value.
+func (t TraceID) Equal(other TraceID) bool {
+	return t == other
+}
+
+// Compare returns -1, 0 or +1 depending on whether t sorts before, equal to
+// or after other, comparing High first and then Low as unsigned numbers. It
+// fits as comparator for slices.SortFunc.
+func (t TraceID) Compare(other TraceID) int {
+	switch {
+	case t.High < other.High:
+		return -1
+	case t.High > other.High:
+		return 1
+	case t.Low < other.Low:
+		return -1
+	case t.Low > other.Low:
+		return 1
+	}
+	return 0
+}
+
+// Less returns if t sorts before other, see Compare.
+func (t TraceID) Less(other TraceID) bool {
+	return t.Compare(other) < 0
+}
+
+// TraceIDs attaches the methods of sort.Interface to []TraceID, sorting in
+// increasing order.
+type TraceIDs []TraceID
+
+func (p TraceIDs) Len() int           { return len(p) }
+func (p TraceIDs) Less(i, j int) bool { return p[i].Less(p[j]) }
+func (p TraceIDs) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+// SortSlice sorts ids in increasing order.
+func SortSlice(ids []TraceID) {
+	sort.Sort(TraceIDs(ids))
+}
+
+// ToHex is an alias for String.
+func (t TraceID) ToHex() string {
+	return t.String()
+}
+
+// TraceIDFromHex returns the TraceID from a 16 (64 bit) or 32 (128 bit)
+// character hex string. Any other length or a non hex character is an error.
 func TraceIDFromHex(h string) (t TraceID, err error) {
-	if len(h) > 16 {
-		if t.High, err = strconv.ParseUint(h[0:len(h)-16], 16, 64); err != nil {
+	switch len(h) {
+	case 16:
+		t.Low, err = hexToUint64(h, 0)
+	case 32:
+		if t.High, err = hexToUint64(h[:16], 0); err != nil {
+			return
+		}
+		t.Low, err = hexToUint64(h[16:], 16)
+	default:
+		err = ErrTraceIDLength.Format(len(h))
+	}
+	return
+}
+
+// ParseHex returns the TraceID from 1 to 32 hex characters. Shorter strings
+// are taken as left padded with zeros, the B3 convention, and anything longer
+// than 16 characters is split into High and Low, so ParseHex is the inverse
+// of String.
+func ParseHex(s string) (t TraceID, err error) {
+	switch {
+	case s == "":
+		err = ErrHexEmpty
+	case len(s) > 32:
+		err = ErrHexTooLong.Format(len(s))
+	case len(s) > 16:
+		if t.High, err = hexToUint64(s[:len(s)-16], 0); err != nil {
 			return
 		}
-		t.Low, err = strconv.ParseUint(h[len(h)-16:], 16, 64)
-		return
+		t.Low, err = hexToUint64(s[len(s)-16:], len(s)-16)
+	default:
+		t.Low, err = hexToUint64(s, 0)
 	}
-	t.Low, err = strconv.ParseUint(h, 16, 64)
 	return
 }
 
+// hexToUint64 decodes at most 16 hex characters of either case. offset is the
+// position of h in the caller's input and only used for error reporting.
+func hexToUint64(h string, offset int) (v uint64, err error) {
+	for i := 0; i < len(h); i++ {
+		c := h[i]
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c -= 'a' - 10
+		case 'A' <= c && c <= 'F':
+			c -= 'A' - 10
+		default:
+			return 0, ErrTraceIDHex.Format(h[i], offset+i)
+		}
+		v = v<<4 | uint64(c)
+	}
+	return
+}
+
+// Bytes returns the TraceID as 16 bytes, High big-endian in bytes 0-7 followed
+// by Low big-endian in bytes 8-15.
+func (t TraceID) Bytes() (b [16]byte) {
+	t.PutBytes(b[:])
+	return
+}
+
+// TraceIDFromBytes returns the TraceID from the layout produced by Bytes.
+func TraceIDFromBytes(b [16]byte) TraceID {
+	return TraceID{
+		High: binary.BigEndian.Uint64(b[:8]),
+		Low:  binary.BigEndian.Uint64(b[8:]),
+	}
+}
+
+// WriteTo writes the 16 bytes returned by Bytes to w.
+func (t TraceID) WriteTo(w io.Writer) (int64, error) {
+	b := t.Bytes()
+	n, err := w.Write(b[:])
+	return int64(n), err
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, returning the 16 bytes
+// of Bytes. Together with UnmarshalBinary it is what encoding/gob uses.
+func (t TraceID) MarshalBinary() ([]byte, error) {
+	b := t.Bytes()
+	return b[:], nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, see FromRawBytes.
+func (t *TraceID) UnmarshalBinary(data []byte) error {
+	tID, err := FromRawBytes(data)
+	if err != nil {
+		return err
+	}
+	*t = tID
+	return nil
+}
+
+// PutBytes writes the 16 bytes of Bytes to the start of dst.
+func (t TraceID) PutBytes(dst []byte) error {
+	if len(dst) < 16 {
+		return ErrShortBuffer.Format(len(dst))
+	}
+	binary.BigEndian.PutUint64(dst[:8], t.High)
+	binary.BigEndian.PutUint64(dst[8:16], t.Low)
+	return nil
+}
+
+// FromRawBytes returns the TraceID from the 16 bytes written by PutBytes and
+// MarshalBinary, or from 8 big-endian bytes of a 64 bit peer which are stored
+// in Low.
+func FromRawBytes(b []byte) (TraceID, error) {
+	switch len(b) {
+	case 8:
+		return TraceID{Low: binary.BigEndian.Uint64(b)}, nil
+	case 16:
+		return TraceID{
+			High: binary.BigEndian.Uint64(b[:8]),
+			Low:  binary.BigEndian.Uint64(b[8:]),
+		}, nil
+	}
+	return TraceID{}, ErrBinaryLength.Format(len(b))
+}
+
+// MarshalText implements encoding.TextMarshaler using the hex representation.
+func (t TraceID) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting what ParseHex
+// accepts. Empty text yields the zero TraceID, the way optional fields arrive.
+func (t *TraceID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*t = TraceID{}
+		return nil
+	}
+	tID, err := ParseHex(string(text))
+	if err != nil {
+		return err
+	}
+	*t = tID
+	return nil
+}
+
 // MarshalJSON custom JSON serializer to export the TraceID in the required
 // zero padded hex representation.
 func (t TraceID) MarshalJSON() ([]byte, error) {
@@ -53,12 +254,26 @@ func (t TraceID) MarshalJSON() ([]byte, error) {
 }
 
 // UnmarshalJSON custom JSON deserializer to retrieve the traceID from the hex
-// encoded representation.
+// encoded representation. For payloads written by older versions it also
+// accepts a decimal string (see Parse for how it is told from hex), the
+// {"High":...,"Low":...} object and null, which yields the zero TraceID.
 func (t *TraceID) UnmarshalJSON(traceID []byte) error {
-	if len(traceID) < 3 {
+	if string(traceID) == "null" {
+		*t = TraceID{}
+		return nil
+	}
+	if len(traceID) > 0 && traceID[0] == '{' {
+		var legacy struct{ High, Low uint64 }
+		if err := json.Unmarshal(traceID, &legacy); err != nil {
+			return err
+		}
+		*t = TraceID(legacy)
+		return nil
+	}
+	if len(traceID) < 3 || traceID[0] != '"' || traceID[len(traceID)-1] != '"' {
 		return ErrValidTraceIDRequired
 	}
-	tID, err := TraceIDFromHex(string(traceID[1 : len(traceID)-1]))
+	tID, err := Parse(string(traceID[1 : len(traceID)-1]))
 	if err != nil {
 		return err
 	}
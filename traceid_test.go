@@ -0,0 +1,252 @@
+package traceid
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/kataras/iris/core/errors"
+)
+
+func TestParseHexRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		id := TraceID{High: rnd.Uint64(), Low: rnd.Uint64()}
+		if i%2 == 0 {
+			id.High = 0
+		}
+		got, err := ParseHex(id.String())
+		if err != nil {
+			t.Fatalf("ParseHex(%q): %v", id.String(), err)
+		}
+		if got != id {
+			t.Fatalf("ParseHex(%q) = %#v, want %#v", id.String(), got, id)
+		}
+	}
+}
+
+func TestParseHex(t *testing.T) {
+	tests := []struct {
+		in   string
+		want TraceID
+		err  errors.Error
+	}{
+		{in: "1", want: TraceID{Low: 1}},
+		{in: "ab", want: TraceID{Low: 0xab}},
+		{in: "00000000000000ab", want: TraceID{Low: 0xab}},
+		{in: "1ffffffffffffffff", want: TraceID{High: 1, Low: ^uint64(0)}},
+		{in: "4BF92F3577B34DA6A3CE929D0E0E4736", want: TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}},
+		{in: "", err: ErrHexEmpty},
+		{in: "4bf92f3577b34da6a3ce929d0e0e47360", err: ErrHexTooLong},
+		{in: "xyz", err: ErrTraceIDHex},
+		{in: "4bf92f3577b34da6a3ce929d0e0e473g", err: ErrTraceIDHex},
+		{in: "4bf92f3577b34da6-3ce929d0e0e4736", err: ErrTraceIDHex},
+	}
+	for _, tt := range tests {
+		got, err := ParseHex(tt.in)
+		if tt.err.NotEmpty() {
+			if err == nil || !tt.err.Equal(err) {
+				t.Errorf("ParseHex(%q) error = %v, want %v", tt.in, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseHex(%q) = %#v, %v, want %#v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseHexErrorPosition(t *testing.T) {
+	_, err := ParseHex("4bf92f3577b34da6a3ce929d0e0e473g")
+	if want := `invalid hex character 'g' at position 31`; err == nil || err.Error() != want {
+		t.Errorf("got %v, want %s", err, want)
+	}
+}
+
+func TestString(t *testing.T) {
+	max := ^uint64(0)
+	tests := []struct {
+		id   TraceID
+		want string
+	}{
+		{TraceID{}, "0000000000000000"},
+		{TraceID{Low: 1}, "0000000000000001"},
+		{TraceID{Low: max}, "ffffffffffffffff"},
+		{TraceID{High: 1}, "00000000000000010000000000000000"},
+		{TraceID{High: 1, Low: 1}, "00000000000000010000000000000001"},
+		{TraceID{High: max, Low: max}, "ffffffffffffffffffffffffffffffff"},
+		{TraceID{High: max, Low: 0xab}, "ffffffffffffffff00000000000000ab"},
+		{TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}, "4bf92f3577b34da6a3ce929d0e0e4736"},
+	}
+	for _, tt := range tests {
+		if got := tt.id.String(); got != tt.want {
+			t.Errorf("%#v.String() = %q, want %q", tt.id, got, tt.want)
+		}
+		if got := tt.id.ToHex(); got != tt.want {
+			t.Errorf("%#v.ToHex() = %q, want %q", tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	type span struct {
+		TraceID TraceID  `json:"trace_id"`
+		Parent  *TraceID `json:"parent,omitempty"`
+	}
+	id := TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}
+	parent := TraceID{Low: 0xab}
+	b, err := json.Marshal(span{TraceID: id, Parent: &parent})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(b), `{"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736","parent":"00000000000000ab"}`; got != want {
+		t.Errorf("Marshal = %s, want %s", got, want)
+	}
+	var got span
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.TraceID != id || got.Parent == nil || *got.Parent != parent {
+		t.Errorf("Unmarshal(%s) = %+v", b, got)
+	}
+
+	m, err := json.Marshal(map[TraceID]int{parent: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(m), `{"00000000000000ab":1}`; got != want {
+		t.Errorf("Marshal of a map = %s, want %s", got, want)
+	}
+}
+
+func TestText(t *testing.T) {
+	for _, id := range []TraceID{
+		{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736},
+		{Low: 0xab},
+	} {
+		text, err := id.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got TraceID
+		if err := got.UnmarshalText(text); err != nil || got != id {
+			t.Errorf("UnmarshalText(%s) = %v, %v, want %v", text, got, err, id)
+		}
+	}
+	got := TraceID{High: 1, Low: 1}
+	if err := got.UnmarshalText(nil); err != nil || !got.IsZero() {
+		t.Errorf("UnmarshalText of empty text = %#v, %v, want the zero TraceID", got, err)
+	}
+	if err := got.UnmarshalText([]byte("xyz")); err == nil {
+		t.Errorf("UnmarshalText(xyz) = %v, want an error", got)
+	}
+
+	var m map[TraceID]int
+	if err := json.Unmarshal([]byte(`{"00000000000000ab":1,"4bf92f3577b34da6a3ce929d0e0e4736":2,"":3}`), &m); err != nil {
+		t.Fatal(err)
+	}
+	want := map[TraceID]int{{Low: 0xab}: 1, {High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}: 2, {}: 3}
+	if len(m) != len(want) {
+		t.Errorf("Unmarshal of a map = %v, want %v", m, want)
+	}
+	for k, v := range want {
+		if m[k] != v {
+			t.Errorf("Unmarshal of a map: [%v] = %d, want %d", k, m[k], v)
+		}
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want TraceID
+	}{
+		{`"4bf92f3577b34da6a3ce929d0e0e4736"`, TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}},
+		{`"00000000000000ab"`, TraceID{Low: 0xab}},
+		{`"ab"`, TraceID{Low: 0xab}},
+		{`"12345"`, TraceID{Low: 12345}},
+		{`"4bf92f35-77b3-4da6-a3ce-929d0e0e4736"`, TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}},
+		{`{"High":5474458728733560230,"Low":11803532876627986230}`, TraceID{High: 0x4bf92f3577b34da6, Low: 0xa3ce929d0e0e4736}},
+		{`{"Low":171}`, TraceID{Low: 0xab}},
+		{`null`, TraceID{}},
+	}
+	for _, tt := range tests {
+		got := TraceID{High: 1, Low: 1}
+		if err := json.Unmarshal([]byte(tt.in), &got); err != nil {
+			t.Errorf("Unmarshal(%s): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Unmarshal(%s) = %#v, want %#v", tt.in, got, tt.want)
+		}
+	}
+	var withNull struct{ TraceID TraceID }
+	if err := json.Unmarshal([]byte(`{"TraceID":null}`), &withNull); err != nil || !withNull.TraceID.IsZero() {
+		t.Errorf("Unmarshal of a null field = %v, %v", withNull.TraceID, err)
+	}
+
+	for _, in := range []string{`""`, `1234`, `"xyz"`, `"4bf92f3577b34da6a3ce929d0e0e47360"`, `{"High":"1"}`, `true`} {
+		var got TraceID
+		if err := json.Unmarshal([]byte(in), &got); err == nil {
+			t.Errorf("Unmarshal(%s) = %v, want an error", in, got)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	const sign = 1 << 63
+	tests := []struct {
+		a, b TraceID
+		want int
+	}{
+		{TraceID{}, TraceID{}, 0},
+		{TraceID{High: 1, Low: 2}, TraceID{High: 1, Low: 2}, 0},
+		{TraceID{High: 1, Low: 1}, TraceID{High: 1, Low: 2}, -1},
+		{TraceID{High: 1, Low: ^uint64(0)}, TraceID{High: 2}, -1},
+		{TraceID{Low: sign - 1}, TraceID{Low: sign}, -1},
+		{TraceID{Low: sign}, TraceID{Low: ^uint64(0)}, -1},
+		{TraceID{High: sign - 1, Low: ^uint64(0)}, TraceID{High: sign}, -1},
+		{TraceID{High: 5, Low: sign}, TraceID{High: 5, Low: 1}, 1},
+		{TraceID{High: ^uint64(0)}, TraceID{High: 1}, 1},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Compare(tt.b); got != tt.want {
+			t.Errorf("%#v.Compare(%#v) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := tt.b.Compare(tt.a); got != -tt.want {
+			t.Errorf("%#v.Compare(%#v) = %d, want %d", tt.b, tt.a, got, -tt.want)
+		}
+		if got := tt.a.Less(tt.b); got != (tt.want < 0) {
+			t.Errorf("%#v.Less(%#v) = %v", tt.a, tt.b, got)
+		}
+		if got := tt.a.Equal(tt.b); got != (tt.want == 0) {
+			t.Errorf("%#v.Equal(%#v) = %v", tt.a, tt.b, got)
+		}
+	}
+}
+
+func TestSortSlice(t *testing.T) {
+	const sign = 1 << 63
+	want := []TraceID{
+		{},
+		{Low: 1},
+		{Low: sign},
+		{High: 1},
+		{High: 1, Low: sign - 1},
+		{High: 1, Low: sign},
+		{High: sign - 1, Low: ^uint64(0)},
+		{High: sign},
+		{High: ^uint64(0), Low: ^uint64(0)},
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		ids := append([]TraceID(nil), want...)
+		rnd.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+		SortSlice(ids)
+		for j := range ids {
+			if ids[j] != want[j] {
+				t.Fatalf("SortSlice = %v, want %v", ids, want)
+			}
+		}
+	}
+}
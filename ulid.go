@@ -0,0 +1,61 @@
+package traceid
+
+import (
+	"github.com/kataras/iris/core/errors"
+)
+
+// crockford is the Crockford base32 alphabet used by ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULID parse errors, use Equal to match them
+var (
+	ErrULIDLength   = errors.New("ulid must be 26 characters, got %d")
+	ErrULIDChar     = errors.New("invalid ulid character %q at position %d")
+	ErrULIDOverflow = errors.New("ulid %q overflows 128 bits")
+)
+
+// ULIDString outputs the TraceID as the 26 character Crockford base32
+// representation used by ULIDs.
+func (t TraceID) ULIDString() string {
+	var b [26]byte
+	high, low := t.High, t.Low
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = crockford[low&0x1f]
+		low = low>>5 | high<<59
+		high >>= 5
+	}
+	return string(b[:])
+}
+
+// ParseULID returns the TraceID from a 26 character ULID string. Letters are
+// accepted in either case.
+func ParseULID(s string) (t TraceID, err error) {
+	if len(s) != 26 {
+		return t, ErrULIDLength.Format(len(s))
+	}
+	if s[0] > '7' {
+		return t, ErrULIDOverflow.Format(s)
+	}
+	for i := 0; i < len(s); i++ {
+		v := crockfordValue(s[i])
+		if v < 0 {
+			return TraceID{}, ErrULIDChar.Format(s[i], i)
+		}
+		t.High = t.High<<5 | t.Low>>59
+		t.Low = t.Low<<5 | uint64(v)
+	}
+	return
+}
+
+// crockfordValue returns the value of a Crockford base32 character, or -1.
+func crockfordValue(c byte) int {
+	if 'a' <= c && c <= 'z' {
+		c -= 'a' - 'A'
+	}
+	for i := 0; i < len(crockford); i++ {
+		if crockford[i] == c {
+			return i
+		}
+	}
+	return -1
+}
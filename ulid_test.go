@@ -0,0 +1,67 @@
+package traceid
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/kataras/iris/core/errors"
+)
+
+func TestULIDString(t *testing.T) {
+	tests := []struct {
+		id   TraceID
+		want string
+	}{
+		{TraceID{}, "00000000000000000000000000"},
+		{TraceID{Low: 1}, "00000000000000000000000001"},
+		{TraceID{Low: 32}, "00000000000000000000000010"},
+		{TraceID{High: 0x01563e3ab5d3d676, Low: 0x4c61efb99302bd5b}, "01ARZ3NDEKTSV4RRFFQ69G5FAV"},
+		{TraceID{High: ^uint64(0), Low: ^uint64(0)}, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+	}
+	for _, tt := range tests {
+		if got := tt.id.ULIDString(); got != tt.want {
+			t.Errorf("%#v.ULIDString() = %q, want %q", tt.id, got, tt.want)
+		}
+		for _, s := range []string{tt.want, strings.ToLower(tt.want)} {
+			if got, err := ParseULID(s); err != nil || got != tt.id {
+				t.Errorf("ParseULID(%q) = %#v, %v, want %#v", s, got, err, tt.id)
+			}
+		}
+	}
+}
+
+func TestULIDRoundTrip(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		id := TraceID{High: rnd.Uint64(), Low: rnd.Uint64()}
+		s := id.ULIDString()
+		if got, err := ParseULID(s); err != nil || got != id {
+			t.Fatalf("ParseULID(%q) = %#v, %v, want %#v", s, got, err, id)
+		}
+	}
+}
+
+func TestParseULIDInvalid(t *testing.T) {
+	tests := []struct {
+		in  string
+		err errors.Error
+	}{
+		{"", ErrULIDLength},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FA", ErrULIDLength},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAVV", ErrULIDLength},
+		{"80000000000000000000000000", ErrULIDOverflow},
+		{"ZZZZZZZZZZZZZZZZZZZZZZZZZZ", ErrULIDOverflow},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAU", ErrULIDChar},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAI", ErrULIDChar},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAL", ErrULIDChar},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FAO", ErrULIDChar},
+		{"01ARZ3NDEK-SV4RRFFQ69G5FAV", ErrULIDChar},
+		{"01ARZ3NDEKTSV4RRFFQ69G5FA\xff", ErrULIDChar},
+	}
+	for _, tt := range tests {
+		if _, err := ParseULID(tt.in); err == nil || !tt.err.Equal(err) {
+			t.Errorf("ParseULID(%q) error = %v, want %v", tt.in, err, tt.err)
+		}
+	}
+}
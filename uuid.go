@@ -0,0 +1,60 @@
+package traceid
+
+import (
+	"fmt"
+
+	"github.com/kataras/iris/core/errors"
+)
+
+// UUID parse errors, use Equal to match them
+var (
+	ErrUUIDLength = errors.New("uuid must be 36 characters, got %d")
+	ErrUUIDDash   = errors.New("uuid %q misses a dash at position %d")
+)
+
+// UUIDString outputs the TraceID in the 8-4-4-4-12 UUID form. The UUID bytes
+// are those returned by Bytes.
+func (t TraceID) UUIDString() string {
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
+		t.High>>32, t.High>>16&0xffff, t.High&0xffff, t.Low>>48, t.Low&0xffffffffffff)
+}
+
+// ParseUUID returns the TraceID from a UUID in the 8-4-4-4-12 form. Hex
+// digits are accepted in either case.
+func ParseUUID(s string) (t TraceID, err error) {
+	if len(s) != 36 {
+		return t, ErrUUIDLength.Format(len(s))
+	}
+	for _, i := range [...]int{8, 13, 18, 23} {
+		if s[i] != '-' {
+			return t, ErrUUIDDash.Format(s, i)
+		}
+	}
+	var v uint64
+	for _, g := range [...]struct{ from, to int }{{0, 8}, {9, 13}, {14, 18}, {19, 23}, {24, 36}} {
+		if v, err = hexToUint64(s[g.from:g.to], g.from); err != nil {
+			return TraceID{}, err
+		}
+		bits := uint(g.to-g.from) * 4
+		t.High = t.High<<bits | t.Low>>(64-bits)
+		t.Low = t.Low<<bits | v
+	}
+	return
+}
+
+// TraceIDFromUUID returns the TraceID from the 16 bytes of a UUID, read
+// big-endian into High and Low.
+func TraceIDFromUUID(u [16]byte) TraceID {
+	return TraceIDFromBytes(u)
+}
+
+// ToUUID returns the TraceID as the 16 bytes of a UUID, the inverse of
+// TraceIDFromUUID.
+func (t TraceID) ToUUID() [16]byte {
+	return t.Bytes()
+}
+
+// TraceIDFromUUIDString is an alias for ParseUUID.
+func TraceIDFromUUIDString(s string) (TraceID, error) {
+	return ParseUUID(s)
+}
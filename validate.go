@@ -0,0 +1,25 @@
+package traceid
+
+import (
+	"github.com/kataras/iris/core/errors"
+)
+
+// validation errors, use Equal to match them
+var (
+	ErrZeroID        = errors.New("trace id is zero")
+	ErrInvalidFormat = errors.New("trace id %s has a zero low half")
+)
+
+// Validate returns ErrZeroID for the zero TraceID and ErrInvalidFormat if Low
+// is zero, which Zipkin rejects and no generator of this module produces.
+// The layout of the upper bits is not checked: every bit pattern of High is
+// a valid random id, see ExtractTimestamp for timestamped ids.
+func Validate(id TraceID) error {
+	switch {
+	case id.IsZero():
+		return ErrZeroID
+	case id.Low == 0:
+		return ErrInvalidFormat.Format(id)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package traceid
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kataras/iris/core/errors"
+)
+
+// ErrXRay is returned by ParseXRay for malformed input.
+var ErrXRay = errors.New("invalid x-ray trace id %q, want 1-<8 hex>-<24 hex>")
+
+// XRayString returns the AWS X-Ray form 1-<8 hex epoch seconds>-<24 hex>
+// of the TraceID. The 24 hex characters are the lower 32 bits of High and
+// Low. The epoch seconds are those of at unless at is zero. With a zero at
+// they come from the upper 32 bits of High, where the timestamped generators
+// of package idgenerator store them, which makes the conversion lossless; a
+// zero there is written as is.
+func (t TraceID) XRayString(at time.Time) string {
+	sec := uint32(t.High >> 32)
+	if !at.IsZero() {
+		sec = uint32(at.Unix())
+	}
+	return fmt.Sprintf("1-%08x-%08x%016x", sec, uint32(t.High), t.Low)
+}
+
+// ParseXRay returns the TraceID and time of an X-Ray trace id, storing the
+// seconds in the upper 32 bits of High the way XRayString reads them.
+func ParseXRay(s string) (TraceID, time.Time, error) {
+	if len(s) != 35 || s[0] != '1' || s[1] != '-' || s[10] != '-' {
+		return TraceID{}, time.Time{}, ErrXRay.Format(s)
+	}
+	sec, err := hexToUint64(s[2:10], 2)
+	if err != nil {
+		return TraceID{}, time.Time{}, err
+	}
+	high, err := hexToUint64(s[11:19], 11)
+	if err != nil {
+		return TraceID{}, time.Time{}, err
+	}
+	low, err := hexToUint64(s[19:], 19)
+	if err != nil {
+		return TraceID{}, time.Time{}, err
+	}
+	return TraceID{High: sec<<32 | high, Low: low}, time.Unix(int64(sec), 0), nil
+}
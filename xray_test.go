@@ -0,0 +1,57 @@
+package traceid
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kataras/iris/core/errors"
+)
+
+func TestParseXRay(t *testing.T) {
+	id, at, err := ParseXRay("1-5759e988-bd862e3fe1be46a994272793")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (TraceID{High: 0x5759e988bd862e3f, Low: 0xe1be46a994272793}); id != want {
+		t.Errorf("id = %#v, want %#v", id, want)
+	}
+	if want := time.Unix(1465510280, 0); !at.Equal(want) {
+		t.Errorf("time = %v, want %v", at, want)
+	}
+	if got := id.XRayString(time.Time{}); got != "1-5759e988-bd862e3fe1be46a994272793" {
+		t.Errorf("XRayString(zero) = %q", got)
+	}
+	if got := id.XRayString(at); got != "1-5759e988-bd862e3fe1be46a994272793" {
+		t.Errorf("XRayString(at) = %q", got)
+	}
+	if got, want := id.XRayString(time.Unix(0x60000000, 0)), "1-60000000-bd862e3fe1be46a994272793"; got != want {
+		t.Errorf("XRayString(other time) = %q, want %q", got, want)
+	}
+	if got, want := (TraceID{Low: 1}).XRayString(time.Time{}), "1-00000000-000000000000000000000001"; got != want {
+		t.Errorf("XRayString of a 64 bit id = %q, want %q", got, want)
+	}
+}
+
+func TestParseXRayInvalid(t *testing.T) {
+	tests := []struct {
+		in  string
+		err errors.Error
+	}{
+		{"", ErrXRay},
+		{"2-5759e988-bd862e3fe1be46a994272793", ErrXRay},
+		{"1_5759e988-bd862e3fe1be46a994272793", ErrXRay},
+		{"1-5759e988_bd862e3fe1be46a994272793", ErrXRay},
+		{"1-5759e98-8bd862e3fe1be46a994272793", ErrXRay},
+		{"1-5759e988-bd862e3fe1be46a99427279", ErrXRay},
+		{"1-5759e988-bd862e3fe1be46a9942727930", ErrXRay},
+		{"5759e988bd862e3fe1be46a994272793", ErrXRay},
+		{"1-5759e98g-bd862e3fe1be46a994272793", ErrTraceIDHex},
+		{"1-5759e988-bd862e3gfe1be46a99427279", ErrTraceIDHex},
+		{"1-5759e988-bd862e3fe1be46a99427279x", ErrTraceIDHex},
+	}
+	for _, tt := range tests {
+		if _, _, err := ParseXRay(tt.in); err == nil || !tt.err.Equal(err) {
+			t.Errorf("ParseXRay(%q) error = %v, want %v", tt.in, err, tt.err)
+		}
+	}
+}
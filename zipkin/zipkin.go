@@ -0,0 +1,30 @@
+/*
+Package zipkin converts between traceid and the model of zipkin-go, so ids
+generated by this module can be handed to the Zipkin reporter.
+*/
+package zipkin
+
+import (
+	"github.com/openzipkin/zipkin-go/model"
+	"github.com/ximply/traceid"
+)
+
+// ToZipkin returns id as a zipkin-go TraceID.
+func ToZipkin(id traceid.TraceID) model.TraceID {
+	return model.TraceID{High: id.High, Low: id.Low}
+}
+
+// FromZipkin returns the TraceID of a zipkin-go TraceID.
+func FromZipkin(id model.TraceID) traceid.TraceID {
+	return traceid.TraceID{High: id.High, Low: id.Low}
+}
+
+// SpanIDToZipkin returns id as a zipkin-go ID.
+func SpanIDToZipkin(id traceid.SpanID) model.ID {
+	return model.ID(id)
+}
+
+// SpanIDFromZipkin returns the SpanID of a zipkin-go ID.
+func SpanIDFromZipkin(id model.ID) traceid.SpanID {
+	return traceid.SpanID(id)
+}